@@ -0,0 +1,150 @@
+package v1alpha1
+
+import (
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionValid reports whether the PlatformConfig spec passed validation.
+	ConditionValid = "Valid"
+	// ConditionReady reports whether controllers for all capabilities defined in the PlatformConfig are running.
+	ConditionReady = "Ready"
+)
+
+// PlatformConfigSpec defines the configuration of platform capabilities. It replaces environment variables
+// and capability configuration files.
+type PlatformConfigSpec struct {
+	// Ingress defines the ingress gateway used to expose routing targets.
+	// +kubebuilder:default={}
+	Ingress IngressSpec `json:"ingress,omitempty"`
+	// Authorization defines the provider enforcing authorization of protected resources.
+	// +kubebuilder:default={}
+	Authorization AuthorizationSpec `json:"authorization,omitempty"`
+	// RoutingTargets lists the resources exposed through the ingress gateway.
+	// +optional
+	RoutingTargets []platform.RoutingTarget `json:"routingTargets,omitempty"`
+	// ProtectedResources lists the resources subject to authorization.
+	// +optional
+	ProtectedResources []platform.ProtectedResource `json:"protectedResources,omitempty"`
+}
+
+// IngressSpec defines the ingress gateway. It corresponds to routing.IngressConfig.
+type IngressSpec struct {
+	// IngressSelectorLabel is the label key selecting the ingress gateway deployment.
+	// +kubebuilder:default=istio
+	IngressSelectorLabel string `json:"ingressSelectorLabel,omitempty"`
+	// IngressSelectorValue is the label value selecting the ingress gateway deployment.
+	// +kubebuilder:default=opendatahub-ingress-gateway
+	IngressSelectorValue string `json:"ingressSelectorValue,omitempty"`
+	// IngressService is the name of the ingress gateway Service.
+	// +kubebuilder:default=opendatahub-ingress-router
+	IngressService string `json:"ingressService,omitempty"`
+	// GatewayNamespace is the namespace of the ingress gateway.
+	// +kubebuilder:default=opendatahub-services
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+}
+
+// AuthorizationSpec defines the authorization provider. It corresponds to authorization.ProviderConfig.
+type AuthorizationSpec struct {
+	// Type selects the mechanism enforcing authorization. It cannot be changed, as resources watched and created
+	// by the controllers depend on it.
+	// +kubebuilder:validation:Enum=authorino;istio
+	// +kubebuilder:default=authorino
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	Type string `json:"type,omitempty"`
+	// Label in a format of key=value targeting created AuthConfigs by Authorino instance.
+	// +kubebuilder:default="security.opendatahub.io/authorization-group=default"
+	Label string `json:"label,omitempty"`
+	// Audiences accepted when performing TokenReview.
+	// +kubebuilder:default={"https://kubernetes.default.svc"}
+	Audiences []string `json:"audiences,omitempty"`
+	// ProviderName is the name of the registered external authorization provider in Service Mesh.
+	// +kubebuilder:default=opendatahub-auth-provider
+	ProviderName string `json:"providerName,omitempty"`
+	// TemplateNamespace is the namespace holding cluster-wide AuthConfig templates ConfigMap.
+	TemplateNamespace string `json:"templateNamespace,omitempty"`
+	// OIDC defines the OpenID Connect provider used by "oidc" auth type.
+	// +kubebuilder:default={}
+	OIDC OIDCSpec `json:"oidc,omitempty"`
+	// ServiceAccountIssuer defines the issuer of service account tokens verified by "istio" provider.
+	// +kubebuilder:default={}
+	ServiceAccountIssuer JWTIssuerSpec `json:"serviceAccountIssuer,omitempty"`
+	// Cache defines caching of TokenReview and SubjectAccessReview results.
+	// +kubebuilder:default={}
+	Cache CacheSpec `json:"cache,omitempty"`
+}
+
+// OIDCSpec defines the OpenID Connect provider. See authorization.OIDCConfig.
+type OIDCSpec struct {
+	IssuerURL string   `json:"issuerURL,omitempty"`
+	Audiences []string `json:"audiences,omitempty"`
+	// +kubebuilder:default=preferred_username
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// +kubebuilder:default=groups
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+}
+
+// JWTIssuerSpec defines the issuer of JWTs. See authorization.JWTIssuerConfig.
+type JWTIssuerSpec struct {
+	// +kubebuilder:default="https://kubernetes.default.svc"
+	IssuerURL string `json:"issuerURL,omitempty"`
+	JWKSURI   string `json:"jwksURI,omitempty"`
+}
+
+// CacheSpec defines caching of Authorino evaluators. See authorization.CacheConfig.
+type CacheSpec struct {
+	// +kubebuilder:default=context.request.http.headers.authorization
+	Key string `json:"key,omitempty"`
+	// TTL is the number of seconds results are cached for. Caching is disabled when zero.
+	// +kubebuilder:validation:Minimum=0
+	TTL int `json:"ttl,omitempty"`
+}
+
+// PlatformConfigStatus reports the outcome of applying the PlatformConfig.
+type PlatformConfigStatus struct {
+	// ObservedGeneration is the generation of the spec the status refers to.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions include ConditionValid and ConditionReady.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ActiveCapabilities lists resource kinds handled by running controllers.
+	ActiveCapabilities CapabilityKinds `json:"activeCapabilities,omitempty"`
+	// PendingCapabilities lists resource kinds which are not served by the API server yet. Controllers for them
+	// are started once their CustomResourceDefinitions are installed.
+	PendingCapabilities CapabilityKinds `json:"pendingCapabilities,omitempty"`
+}
+
+// CapabilityKinds lists GroupVersionKinds, e.g. "serving.kserve.io/v1beta1, Kind=InferenceService", per capability.
+type CapabilityKinds struct {
+	Routing       []string `json:"routing,omitempty"`
+	Authorization []string `json:"authorization,omitempty"`
+}
+
+// PlatformConfig is the single point of configuration of the platform capabilities.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type PlatformConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PlatformConfigSpec   `json:"spec,omitempty"`
+	Status PlatformConfigStatus `json:"status,omitempty"`
+}
+
+// PlatformConfigList contains a list of PlatformConfig.
+// +kubebuilder:object:root=true
+type PlatformConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlatformConfig `json:"items"`
+}
+
+func init() { //nolint:gochecknoinits //reason: scheme registration
+	SchemeBuilder.Register(&PlatformConfig{}, &PlatformConfigList{})
+}
@@ -0,0 +1,30 @@
+package v1alpha1
+
+import (
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate checks the constraints of the spec which cannot be expressed in the CRD schema,
+// such as distinct resource kinds of capability entries.
+func (s PlatformConfigSpec) Validate() field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	var errs field.ErrorList
+
+	if len(s.RoutingTargets) > 0 {
+		ingressPath := specPath.Child("ingress")
+		if s.Ingress.IngressService == "" {
+			errs = append(errs, field.Required(ingressPath.Child("ingressService"), "required to expose routing targets"))
+		}
+
+		if s.Ingress.GatewayNamespace == "" {
+			errs = append(errs, field.Required(ingressPath.Child("gatewayNamespace"), "required to expose routing targets"))
+		}
+	}
+
+	errs = append(errs, platform.ValidateRoutingTargets(s.RoutingTargets, specPath.Child("routingTargets"))...)
+	errs = append(errs, platform.ValidateProtectedResources(s.ProtectedResources, specPath.Child("protectedResources"))...)
+
+	return errs
+}
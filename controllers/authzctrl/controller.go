@@ -4,23 +4,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	authorinov1beta2 "github.com/kuadrant/authorino/api/v1beta2"
 	platformctrl "github.com/opendatahub-io/odh-platform/controllers"
 	"github.com/opendatahub-io/odh-platform/pkg/authorization"
+	"github.com/opendatahub-io/odh-platform/pkg/logging"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
+	"github.com/opendatahub-io/odh-platform/pkg/metrics"
 	"github.com/opendatahub-io/odh-platform/pkg/platform"
 	"github.com/opendatahub-io/odh-platform/pkg/spi"
+	"github.com/opendatahub-io/odh-platform/pkg/tracing"
+	"github.com/opendatahub-io/odh-platform/pkg/unstruct"
 	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const name = "authorization"
@@ -30,24 +45,32 @@ func New(cli client.Client, log logr.Logger,
 	return &Controller{
 		active: true,
 		Client: cli,
-		log: log.WithValues(
+		log: log.WithName(controllerName(protectedResource.ResourceReference.Kind)).WithValues(
 			"controller", name,
 			"component", protectedResource.ResourceReference.Kind,
 		),
 		config:            config,
 		protectedResource: protectedResource,
-		typeDetector:      authorization.NewAnnotationAuthTypeDetector(annotations.AuthEnabled("").Key()),
-		// TODO: Evaluate passing in the hostExtractor to avoid coupling the authorizaiton/routing packages
-		hostExtractor: spi.UnifiedHostExtractor(
-			spi.NewPathExpressionExtractor(protectedResource.HostPaths),
-			spi.NewAnnotationHostExtractor(";", metadata.Keys(annotations.RoutingAddressesExternal(""), annotations.RoutingAddressesPublic(""))...)),
-		templateLoader: authorization.NewConfigMapTemplateLoader(cli, authorization.NewStaticTemplateLoader()),
+		typeDetector:      authorization.NewAnnotationAuthTypeDetector(annotations.AuthEnabled("").Key(), annotations.AuthType("").Key()),
+		hostExtractor:     newHostExtractor(protectedResource),
+		templateLoader:    authorization.NewConfigMapTemplateLoader(cli, config.TemplateNamespace, authorization.NewStaticTemplateLoader()),
+		requeue:           make(chan event.GenericEvent),
+		drift:             metrics.NewDriftDetector(metrics.Authorization),
 	}
 }
 
+// TODO: Evaluate passing in the hostExtractor to avoid coupling the authorizaiton/routing packages
+func newHostExtractor(protectedResource platform.ProtectedResource) spi.HostExtractor {
+	return spi.UnifiedHostExtractor(
+		spi.NewPathExpressionExtractor(protectedResource.HostPaths),
+		spi.NewAnnotationHostExtractor(";", metadata.Keys(annotations.RoutingAddressesExternal(""), annotations.RoutingAddressesPublic(""))...))
+}
+
 // Controller holds the authorization controller configuration.
 type Controller struct {
 	client.Client
+	// mu guards the configuration, which can be changed at runtime while resources are being reconciled.
+	mu                sync.RWMutex
 	active            bool
 	log               logr.Logger
 	config            authorization.ProviderConfig
@@ -55,29 +78,44 @@ type Controller struct {
 	typeDetector      authorization.AuthTypeDetector
 	hostExtractor     spi.HostExtractor
 	templateLoader    authorization.AuthConfigTemplateLoader
+	recorder          record.EventRecorder
+	requeue           chan event.GenericEvent
+	drift             *metrics.DriftDetector
+	// controller and cache are used to watch Services once port discovery gets enabled.
+	controller       controller.Controller
+	cache            cache.Cache
+	watchingServices bool
 }
 
 // +kubebuilder:rbac:groups=authorino.kuadrant.io,resources=authconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=security.istio.io,resources=authorizationpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=security.istio.io,resources=requestauthentications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=security.istio.io,resources=peerauthentications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile ensures that the component has all required resources needed to use authorization capability of the platform.
-func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, errReconcile error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ctx, log, span := platformctrl.StartReconcile(ctx, r.log, r.Name(), r.protectedResource.ResourceReference.GroupVersionKind, req)
+	defer func() { tracing.End(span, errReconcile) }()
+
 	if !r.active {
-		r.log.V(5).Info("controller is not active")
+		log.V(5).Info("controller is not active")
 
 		return ctrl.Result{}, nil
 	}
 
-	// NOTE: r.reconcilePeerAuthentication removed in https://github.com/maistra/odh-platform/pull/53
-	// Revert if removal thesis breaks down
-	reconcilers := []platformctrl.SubReconcileFunc{r.reconcileAuthConfig, r.reconcileAuthPolicy}
-
 	sourceRes := &unstructured.Unstructured{}
 	sourceRes.SetGroupVersionKind(r.protectedResource.ResourceReference.GroupVersionKind)
 
 	if err := r.Client.Get(ctx, req.NamespacedName, sourceRes); err != nil {
 		if k8serr.IsNotFound(err) {
-			r.log.Info("skipping reconcile. resource does not exist anymore", "resource", sourceRes.GroupVersionKind().String())
+			log.Info("skipping reconcile. resource does not exist anymore")
+			metrics.ForgetComponent(metrics.Authorization, r.protectedResource.ResourceReference.Kind, req.Namespace, req.Name)
+			r.drift.Forget(req.NamespacedName)
 
 			return ctrl.Result{}, nil
 		}
@@ -85,50 +123,300 @@ func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, fmt.Errorf("failed getting resource: %w", err)
 	}
 
-	r.log.Info("triggered auth reconcile", "namespace", req.Namespace, "name", req.Name)
+	log = log.WithValues(logging.OwnerUIDKey, sourceRes.GetUID())
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	log.Info("triggered auth reconcile")
+
+	reconcilers := r.reconcilers()
+
+	if isOptedOut(sourceRes) {
+		log.Info("component opted out of authorization")
+
+		reconcilers = []platformctrl.SubReconcileFunc{r.removeAuthResources}
+	} else {
+		ctx = r.withWorkloadSelector(ctx, sourceRes)
+	}
+
+	originalRes := sourceRes.DeepCopy()
 
 	var errs []error
+
 	for _, reconciler := range reconcilers {
-		errs = append(errs, reconciler(ctx, sourceRes))
+		if errStep := platformctrl.Traced(reconciler)(ctx, sourceRes); errStep != nil {
+			metrics.ReconcileFailed(metrics.Authorization, sourceRes, reconciler.Name())
+			errs = append(errs, errStep)
+		}
+	}
+
+	r.recordAuthType(ctx, sourceRes)
+
+	if !maps.Equal(originalRes.GetAnnotations(), sourceRes.GetAnnotations()) {
+		errPatch := unstruct.PatchFrom(ctx, r.Client, originalRes, sourceRes)
+		if k8serr.IsConflict(errPatch) && len(errs) == 0 {
+			log.Info("resource modified while reconciling, requeueing")
+
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		errs = append(errs, errPatch)
 	}
 
 	return ctrl.Result{}, errors.Join(errs...)
 }
 
+// recordAuthType records the auth type used to protect the target, unless it cannot be detected.
+func (r *Controller) recordAuthType(ctx context.Context, target *unstructured.Unstructured) {
+	if isOptedOut(target) {
+		metrics.SetAuthType(target, metrics.NoAuthorization)
+
+		return
+	}
+
+	if authType, errDetect := r.typeDetector.Detect(ctx, target); errDetect == nil {
+		metrics.SetAuthType(target, string(authType))
+	}
+}
+
+// reconcilers returns sub-reconcilers creating resources required by the configured authorization provider.
+func (r *Controller) reconcilers() []platformctrl.SubReconcileFunc {
+	if r.config.GetType() == authorization.IstioProvider {
+		return []platformctrl.SubReconcileFunc{r.reconcileRequestAuthentication, r.reconcileDenyPolicy, r.reconcilePeerAuthentication}
+	}
+
+	return []platformctrl.SubReconcileFunc{r.reconcileAuthConfig, r.reconcileAuthPolicy, r.reportAuthConfigStatus, r.reconcilePeerAuthentication}
+}
+
+// ownedResources returns types of resources created for the watched resource by the configured authorization provider.
+// PeerAuthentication is always included, so that the one created before it has been disabled can be removed.
+func (r *Controller) ownedResources() []client.Object {
+	if r.config.GetType() == authorization.IstioProvider {
+		return []client.Object{
+			&istiosecurityv1beta1.RequestAuthentication{},
+			&istiosecurityv1beta1.AuthorizationPolicy{},
+			&istiosecurityv1beta1.PeerAuthentication{},
+		}
+	}
+
+	return []client.Object{
+		&authorinov1beta2.AuthConfig{},
+		&istiosecurityv1beta1.AuthorizationPolicy{},
+		&istiosecurityv1beta1.PeerAuthentication{},
+	}
+}
+
 func (r *Controller) Name() string {
-	return name + "-" + strings.ToLower(r.protectedResource.ResourceReference.Kind)
+	return controllerName(r.protectedResource.ResourceReference.Kind)
+}
+
+// controllerName is unique for each component kind. It also names the logger of the controller, so that
+// its verbosity can be configured separately.
+func controllerName(kind string) string {
+	return name + "-" + strings.ToLower(kind)
 }
 
 func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	if r.Client == nil {
 		// Ensures client is set - fall back to the one defined for the passed manager
 		r.Client = mgr.GetClient()
+		r.templateLoader = authorization.NewConfigMapTemplateLoader(r.Client, r.config.TemplateNamespace, authorization.NewStaticTemplateLoader())
 	}
 
+	r.recorder = mgr.GetEventRecorderFor(r.Name())
+
 	// TODO(mvp): define predicates so we do not reconcile unnecessarily
-	//nolint:wrapcheck //reason there is no point in wrapping it
-	return ctrl.NewControllerManagedBy(mgr).
+	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		Named(r.Name()).
 		For(&metav1.PartialObjectMetadata{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: r.protectedResource.ResourceReference.GroupVersion().String(),
 				Kind:       r.protectedResource.ResourceReference.Kind,
 			},
-		}, builder.OnlyMetadata).
-		Owns(&authorinov1beta2.AuthConfig{}).
-		Owns(&istiosecurityv1beta1.AuthorizationPolicy{}).
-		Complete(r)
+		}, builder.OnlyMetadata)
+
+	// Only resources of the configured provider are watched, as e.g. Authorino CRDs might not be present in the cluster.
+	for _, owned := range r.ownedResources() {
+		ctrlBuilder = ctrlBuilder.Owns(owned)
+	}
+
+	if r.config.GetType() == authorization.AuthorinoProvider {
+		ctrlBuilder = ctrlBuilder.Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findTargetsUsingTemplate),
+			builder.WithPredicates(predicate.NewPredicateFuncs(authorization.IsTemplateConfigMap)),
+		)
+	}
+
+	ctrlBuilder = ctrlBuilder.WatchesRawSource(&source.Channel{Source: r.requeue}, &handler.EnqueueRequestForObject{})
+
+	ctrlr, errBuild := ctrlBuilder.Build(r)
+	if errBuild != nil {
+		return errBuild //nolint:wrapcheck //reason there is no point in wrapping it
+	}
+
+	r.controller = ctrlr
+	r.cache = mgr.GetCache()
+
+	if r.protectedResource.PortDiscovery {
+		return r.watchServices()
+	}
+
+	return nil
+}
+
+// watchServices starts watching Services when port discovery is enabled for the first time. Services are then
+// watched until the manager stops, and changes are ignored while port discovery is disabled.
+func (r *Controller) watchServices() error {
+	if r.watchingServices {
+		return nil
+	}
+
+	if errWatch := r.controller.Watch(source.Kind(r.cache, &corev1.Service{}),
+		handler.EnqueueRequestsFromMapFunc(r.findTargetsDiscoveringPorts)); errWatch != nil {
+		return fmt.Errorf("unable to watch services for port discovery: %w", errWatch)
+	}
+
+	r.watchingServices = true
+
+	return nil
+}
+
+// findTargetsUsingTemplate enqueues all watched resources affected by the change of AuthConfig templates ConfigMap.
+// When the ConfigMap is the cluster-wide one, all watched resources are enqueued.
+func (r *Controller) findTargetsUsingTemplate(ctx context.Context, templateCM client.Object) []reconcile.Request {
+	r.mu.RLock()
+	templateNamespace := r.config.TemplateNamespace
+	r.mu.RUnlock()
+
+	var listOpts []client.ListOption
+	if templateCM.GetNamespace() != templateNamespace {
+		listOpts = append(listOpts, client.InNamespace(templateCM.GetNamespace()))
+	}
+
+	return r.findTargets(ctx, templateCM, listOpts...)
+}
+
+// findTargetsDiscoveringPorts enqueues all watched resources in the namespace of the changed Service,
+// as their ports might be discovered from it. Nothing is enqueued when port discovery is not enabled.
+func (r *Controller) findTargetsDiscoveringPorts(ctx context.Context, svc client.Object) []reconcile.Request {
+	r.mu.RLock()
+	portDiscovery := r.protectedResource.PortDiscovery
+	r.mu.RUnlock()
+
+	if !portDiscovery {
+		return nil
+	}
+
+	return r.findTargets(ctx, svc, client.InNamespace(svc.GetNamespace()))
+}
+
+func (r *Controller) findTargets(ctx context.Context, changed client.Object, listOpts ...client.ListOption) []reconcile.Request {
+	gvk := r.protectedResource.ResourceReference.GroupVersionKind
+
+	targets := &metav1.PartialObjectMetadataList{}
+	targets.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := r.Client.List(ctx, targets, listOpts...); err != nil {
+		r.log.Error(err, "failed listing resources affected by the change", r.resourceValues(changed)...)
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(targets.Items))
+	for i := range targets.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&targets.Items[i])}
+	}
+
+	return requests
 }
 
 var _ platformctrl.Activable[authorization.ProviderConfig] = &Controller{}
 
 func (r *Controller) Activate(config authorization.ProviderConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if config.GetType() != r.config.GetType() {
+		// Watches and owned resources are set up for the provider type when the controller is created,
+		// so resources created by the other provider would be neither watched nor removed.
+		r.log.Error(errors.New("provider type cannot be changed at runtime"), "keeping the current provider type, restart is required",
+			"current", r.config.GetType(), "requested", config.GetType())
+
+		config.Type = r.config.Type
+	}
+
 	r.active = true
 	r.config = config
+	r.templateLoader = authorization.NewConfigMapTemplateLoader(r.Client, config.TemplateNamespace, authorization.NewStaticTemplateLoader())
 }
 
 func (r *Controller) Deactivate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.active = false
+
+	metrics.ForgetKind(metrics.Authorization, r.protectedResource.ResourceReference.Kind)
+}
+
+var _ platformctrl.Reconfigurable[platform.ProtectedResource] = &Controller{}
+
+// Reconfigure replaces the ProtectedResource definition. Its GVK is expected to stay the same.
+func (r *Controller) Reconfigure(protectedResource platform.ProtectedResource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.protectedResource = protectedResource
+	r.hostExtractor = newHostExtractor(protectedResource)
+
+	if protectedResource.PortDiscovery && r.controller != nil {
+		if errWatch := r.watchServices(); errWatch != nil {
+			r.log.Error(errWatch, "ports will not be updated when services change")
+		}
+	}
+}
+
+// Requeue enqueues all watched resources. Events are delivered asynchronously, as the controller might not be
+// started yet (e.g. when waiting for leader election).
+func (r *Controller) Requeue(ctx context.Context) error {
+	r.mu.RLock()
+	gvk := r.protectedResource.ResourceReference.GroupVersionKind
+	r.mu.RUnlock()
+
+	targets := &metav1.PartialObjectMetadataList{}
+	targets.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := r.Client.List(ctx, targets); err != nil {
+		return fmt.Errorf("failed listing resources to requeue: %w", err)
+	}
+
+	go func() {
+		for i := range targets.Items {
+			select {
+			case r.requeue <- event.GenericEvent{Object: &targets.Items[i]}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// apply ensures the desired state of the resource using server-side apply, so that only fields owned by the platform
+// are enforced, while changes made by other parties to the remaining fields (e.g. labels or annotations) are preserved.
+func (r *Controller) apply(ctx context.Context, target *unstructured.Unstructured, desired client.Object) error {
+	desiredUnstructured, errConvert := unstruct.ToUnstructured(desired, r.Scheme())
+	if errConvert != nil {
+		return fmt.Errorf("unable to convert desired resource: %w", errConvert)
+	}
+
+	if errApply := unstruct.ServerSideApply(ctx, r.Client, []*unstructured.Unstructured{desiredUnstructured}, r.drift.Observe(target)); errApply != nil {
+		return errApply //nolint:wrapcheck //reason errors returned by unstruct are already wrapped
+	}
+
+	metrics.SetManagedResources(metrics.Authorization, target, desiredUnstructured.GroupVersionKind().GroupKind(), 1)
+
+	return nil
 }
 
 func targetToOwnerRef(obj *unstructured.Unstructured) metav1.OwnerReference {
@@ -7,8 +7,11 @@ import (
 	authorinov1beta2 "github.com/kuadrant/authorino/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/labels"
 	"github.com/opendatahub-io/odh-platform/test"
 	. "github.com/opendatahub-io/odh-platform/test/matchers"
 	"istio.io/api/security/v1beta1"
@@ -18,12 +21,28 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+const customAnonymousTemplate = `
+apiVersion: authorino.kuadrant.io/v1beta2
+kind: AuthConfig
+metadata:
+  labels:
+    security.opendatahub.io/authorization-group: default
+    template-namespace: {{ .Namespace }}
+spec:
+  hosts:
+  - "UPDATED.RUNTIME"
+  authentication:
+    custom-anonymous-access:
+      anonymous: {}
+`
+
 const watchedCR = `
 apiVersion: opendatahub.io/v1
-kind: Component
+kind: %[3]s
 metadata:
   name: %[1]s
   namespace: %[2]s
@@ -42,26 +61,8 @@ var _ = Describe("Checking Authorization Resource Creation", test.EnvTest(), fun
 
 	BeforeEach(func(ctx context.Context) {
 		resourceName = "test-component"
-		base := "test-namespace"
-		testNamespaceName = fmt.Sprintf("%s%s", base, utilrand.String(7))
-
-		testNamespace = &corev1.Namespace{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: "v1",
-				Kind:       "Namespace",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name: testNamespaceName,
-			},
-		}
-		_, err := controllerutil.CreateOrUpdate(ctx, envTest.Client, testNamespace, func() error {
-			return nil
-		})
-		Expect(err).ToNot(HaveOccurred())
-
-		var errCreate error
-		createdComponent, errCreate = test.CreateResource(ctx, envTest.Client, componentResource(resourceName, testNamespaceName))
-		Expect(errCreate).ToNot(HaveOccurred())
+		createdComponent, testNamespace = createComponent(ctx, "Component", resourceName)
+		testNamespaceName = testNamespace.Name
 	})
 
 	AfterEach(func() {
@@ -133,6 +134,291 @@ var _ = Describe("Checking Authorization Resource Creation", test.EnvTest(), fun
 			Should(Succeed())
 	})
 
+	It("should check access to the protected resource instance when annotation is specified", func(ctx context.Context) {
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent, annotations.AuthEnabled("true"))
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthConfig)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthConfig.Spec.Authorization).To(HaveKey("kubernetes-rbac"))
+
+			sar := createdAuthConfig.Spec.Authorization["kubernetes-rbac"].KubernetesSubjectAccessReview
+			g.Expect(sar).ToNot(BeNil())
+			g.Expect(sar.ResourceAttributes).ToNot(BeNil())
+			// ProtectedResource defined in suite_test
+			g.Expect(sar.ResourceAttributes.Group.Value.Raw).To(MatchJSON(`"opendatahub.io"`))
+			g.Expect(sar.ResourceAttributes.Resource.Value.Raw).To(MatchJSON(`"components"`))
+			g.Expect(sar.ResourceAttributes.Name.Value.Raw).To(MatchJSON(`"` + resourceName + `"`))
+			g.Expect(sar.ResourceAttributes.Namespace.Value.Raw).To(MatchJSON(`"` + testNamespaceName + `"`))
+			g.Expect(sar.ResourceAttributes.Verb.Value.Raw).To(MatchJSON(`"get"`))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should skip access check for groups and users allowed using annotations", func(ctx context.Context) {
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent,
+				annotations.AuthEnabled("true"),
+				annotations.AllowedGroups("data-science"),
+				annotations.AllowedUsers("alice"),
+			)
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthConfig)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthConfig.Spec.Authorization).To(HaveKey("kubernetes-rbac"))
+			g.Expect(createdAuthConfig.Spec.Authorization).NotTo(HaveKey("allowed-identities"))
+			g.Expect(createdAuthConfig.Spec.Authorization["kubernetes-rbac"].Conditions).To(ConsistOf(
+				HaveField("PatternExpression", authorinov1beta2.PatternExpression{
+					Selector: "auth.identity.groups", Operator: "excl", Value: "data-science",
+				}),
+				HaveField("PatternExpression", authorinov1beta2.PatternExpression{
+					Selector: "auth.identity.username", Operator: "neq", Value: "alice",
+				}),
+			))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should not pass identity headers unless defined for the ProtectedResource", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthConfig)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthConfig.Spec.Response).To(BeNil())
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should cache results of access checks when requested using annotation", func(ctx context.Context) {
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent,
+				annotations.AuthEnabled("true"),
+				annotations.AuthCacheTTL("30"),
+				annotations.AuthCacheKey("context.request.http.headers.authorization"),
+			)
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthConfig)
+
+			if err != nil {
+				return err
+			}
+
+			expectedCache := &authorinov1beta2.EvaluatorCaching{
+				Key: authorinov1beta2.ValueOrSelector{Selector: "context.request.http.headers.authorization"},
+				TTL: 30,
+			}
+			g.Expect(createdAuthConfig.Spec.Authentication["kubernetes-user"].Cache).To(Equal(expectedCache))
+			g.Expect(createdAuthConfig.Spec.Authorization["kubernetes-rbac"].Cache).To(Equal(expectedCache))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should report cache key which does not identify the caller as event", func(ctx context.Context) {
+		// given
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent,
+				annotations.AuthEnabled("true"),
+				annotations.AuthCacheTTL("30"),
+				annotations.AuthCacheKey("context.request.http.path"),
+			)
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) error {
+			events := &corev1.EventList{}
+			if err := envTest.Client.List(ctx, events, client.InNamespace(testNamespaceName)); err != nil {
+				return err
+			}
+
+			g.Expect(events.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Reason": Equal("InvalidAuthCacheKey"),
+				"Type":   Equal(corev1.EventTypeWarning),
+				"InvolvedObject": MatchFields(IgnoreExtras, Fields{
+					"Name": Equal(resourceName),
+				}),
+			})))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should report required claims which cannot be enforced without OIDC authentication as event", func(ctx context.Context) {
+		// given
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent,
+				annotations.AuthEnabled("true"),
+				annotations.AuthType("userdefined"),
+				annotations.RequiredClaims("email_verified=true"),
+			)
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) error {
+			events := &corev1.EventList{}
+			if err := envTest.Client.List(ctx, events, client.InNamespace(testNamespaceName)); err != nil {
+				return err
+			}
+
+			g.Expect(events.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Reason": Equal("UnsupportedRequiredClaims"),
+				"Type":   Equal(corev1.EventTypeWarning),
+				"InvolvedObject": MatchFields(IgnoreExtras, Fields{
+					"Name": Equal(resourceName),
+				}),
+			})))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should report allowed groups and users which cannot be enforced without user identity as event", func(ctx context.Context) {
+		// given
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			// anonymous access is used by default
+			metadata.ApplyMetaOptions(createdComponent, annotations.AllowedGroups("data-science"))
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) error {
+			events := &corev1.EventList{}
+			if err := envTest.Client.List(ctx, events, client.InNamespace(testNamespaceName)); err != nil {
+				return err
+			}
+
+			g.Expect(events.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Reason": Equal("UnsupportedIdentityAllowList"),
+				"Type":   Equal(corev1.EventTypeWarning),
+				"InvolvedObject": MatchFields(IgnoreExtras, Fields{
+					"Name": Equal(resourceName),
+				}),
+			})))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should create an API key AuthConfig resource selecting secrets owned by the component", func(ctx context.Context) {
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent,
+				annotations.AuthEnabled("true"),
+				annotations.AuthType("apikey"),
+			)
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthConfig)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthConfig).To(HaveAuthenticationMethod("api-key"))
+			g.Expect(createdAuthConfig.Spec.Authentication["api-key"].ApiKey.Selector.MatchLabels).To(Equal(map[string]string(
+				labels.MatchingLabels(
+					labels.APIKey("true"),
+					labels.OwnerKind("Component"),
+					labels.OwnerName(resourceName),
+				),
+			)))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
 	It("should create an AuthorizationPolicy when a Component is created", func(ctx context.Context) {
 		Eventually(func(g Gomega, ctx context.Context) error {
 			createdAuthPolicy := &istiosecurityv1beta1.AuthorizationPolicy{}
@@ -146,7 +432,7 @@ var _ = Describe("Checking Authorization Resource Creation", test.EnvTest(), fun
 			}
 
 			g.Expect(createdAuthPolicy.Spec.GetAction()).To(Equal(v1beta1.AuthorizationPolicy_CUSTOM))
-			// WorkloadSelector expresssion defined in suite_test
+			// WorkloadSelector expression defined in suite_test
 			g.Expect(createdAuthPolicy.Spec.GetSelector().GetMatchLabels()).To(HaveKeyWithValue("component", resourceName))
 
 			return nil
@@ -157,32 +443,74 @@ var _ = Describe("Checking Authorization Resource Creation", test.EnvTest(), fun
 			Should(Succeed())
 	})
 
-	// Using k8s envtest we are not able to test actual garbage collection of resources. [1]
-	// Therefore, we ensure we have correct ownerRefs set.
-	//
-	// [1] https://book.kubebuilder.io/reference/envtest#testing-considerations
-	It("should have ownerReference on all created auth resources", func(ctx context.Context) {
+	It("should create an AuthConfig from the template defined in the namespace ConfigMap", func(ctx context.Context) {
+		// given
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      authorization.TemplateConfigMapName,
+				Namespace: testNamespaceName,
+			},
+			Data: map[string]string{
+				string(authorization.Anonymous): customAnonymousTemplate,
+			},
+		}
+		Expect(envTest.Client.Create(ctx, templateCM)).To(Succeed())
+		defer envTest.DeleteAll(templateCM)
+
+		// then
 		Eventually(func(g Gomega, ctx context.Context) error {
-			createdResource := &istiosecurityv1beta1.AuthorizationPolicy{}
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
 			err := envTest.Client.Get(ctx, types.NamespacedName{
 				Name:      resourceName,
 				Namespace: testNamespaceName,
-			}, createdResource)
+			}, createdAuthConfig)
 
 			if err != nil {
 				return err
 			}
 
-			ctrl := true
-			expectedOwnerRef := metav1.OwnerReference{
-				APIVersion: createdComponent.GetAPIVersion(),
-				Kind:       createdComponent.GetKind(),
-				Name:       createdComponent.GetName(),
-				UID:        createdComponent.GetUID(),
-				Controller: &ctrl,
+			g.Expect(createdAuthConfig).To(HaveHosts("example.com"))
+			g.Expect(createdAuthConfig.Labels).To(HaveKeyWithValue("security.opendatahub.io/authorization-group", "default"))
+			g.Expect(createdAuthConfig.Labels).To(HaveKeyWithValue("template-namespace", testNamespaceName))
+			g.Expect(createdAuthConfig).To(HaveAuthenticationMethod("custom-anonymous-access"))
+			g.Expect(createdAuthConfig).NotTo(HaveAuthenticationMethod("anonymous-access"))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should report invalid template defined in the namespace ConfigMap as event", func(ctx context.Context) {
+		// given
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      authorization.TemplateConfigMapName,
+				Namespace: testNamespaceName,
+			},
+			Data: map[string]string{
+				string(authorization.Anonymous): "{{ .Namespace",
+			},
+		}
+		Expect(envTest.Client.Create(ctx, templateCM)).To(Succeed())
+		defer envTest.DeleteAll(templateCM)
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) error {
+			events := &corev1.EventList{}
+			if err := envTest.Client.List(ctx, events, client.InNamespace(testNamespaceName)); err != nil {
+				return err
 			}
 
-			g.Expect(createdResource.OwnerReferences).To(ContainElement(expectedOwnerRef))
+			g.Expect(events.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Reason": Equal("InvalidAuthConfigTemplate"),
+				"Type":   Equal(corev1.EventTypeWarning),
+				"InvolvedObject": MatchFields(IgnoreExtras, Fields{
+					"Name": Equal(resourceName),
+				}),
+			})))
 
 			return nil
 		}).
@@ -191,8 +519,584 @@ var _ = Describe("Checking Authorization Resource Creation", test.EnvTest(), fun
 			WithPolling(test.DefaultPolling).
 			Should(Succeed())
 	})
-})
 
-func componentResource(name, namespace string) []byte {
-	return []byte(fmt.Sprintf(watchedCR, name, namespace))
+	It("should remove and recreate auth resources when component opts out and back in", func(ctx context.Context) {
+		authResourcesExist := func(g Gomega, ctx context.Context) (bool, bool) {
+			authConfigErr := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, &authorinov1beta2.AuthConfig{})
+			g.Expect(client.IgnoreNotFound(authConfigErr)).ToNot(HaveOccurred())
+
+			authPolicyErr := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, &istiosecurityv1beta1.AuthorizationPolicy{})
+			g.Expect(client.IgnoreNotFound(authPolicyErr)).ToNot(HaveOccurred())
+
+			return authConfigErr == nil, authPolicyErr == nil
+		}
+
+		// given
+		Eventually(func(g Gomega, ctx context.Context) {
+			authConfigExists, authPolicyExists := authResourcesExist(g, ctx)
+			g.Expect(authConfigExists).To(BeTrue())
+			g.Expect(authPolicyExists).To(BeTrue())
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+
+		// when
+		_, errOptOut := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent, annotations.AuthDisabled())
+
+			return nil
+		})
+		Expect(errOptOut).ToNot(HaveOccurred())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) {
+			authConfigExists, authPolicyExists := authResourcesExist(g, ctx)
+			g.Expect(authConfigExists).To(BeFalse())
+			g.Expect(authPolicyExists).To(BeFalse())
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+
+		// when
+		_, errOptIn := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent, annotations.Remove(annotations.AuthDisabled()))
+
+			return nil
+		})
+		Expect(errOptIn).ToNot(HaveOccurred())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) {
+			authConfigExists, authPolicyExists := authResourcesExist(g, ctx)
+			g.Expect(authConfigExists).To(BeTrue())
+			g.Expect(authPolicyExists).To(BeTrue())
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should propagate AuthConfig readiness back to the component", func(ctx context.Context) {
+		// given
+		componentAnnotations := func(g Gomega, ctx context.Context) map[string]string {
+			updatedComponent := createdComponent.DeepCopy()
+			g.Expect(envTest.Client.Get(ctx, client.ObjectKeyFromObject(updatedComponent), updatedComponent)).To(Succeed())
+
+			return updatedComponent.GetAnnotations()
+		}
+
+		// then
+		// there is no Authorino instance running in the test environment
+		Eventually(func(g Gomega, ctx context.Context) {
+			g.Expect(componentAnnotations(g, ctx)).To(HaveKeyWithValue(annotations.AuthConfigReady("").Key(), "false"))
+			g.Expect(componentAnnotations(g, ctx)).To(HaveKeyWithValue(annotations.AuthConfigStatus("").Key(), HavePrefix("NotReconciled:")))
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+
+		// when
+		// another controller annotates the component in the meantime
+		_, errUpdate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent, annotations.RoutingAddressesPublic("test-component.example.com"))
+
+			return nil
+		})
+		Expect(errUpdate).ToNot(HaveOccurred())
+
+		Eventually(func(ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			if err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthConfig); err != nil {
+				return err
+			}
+
+			createdAuthConfig.Status = authorinov1beta2.AuthConfigStatus{
+				Conditions: []authorinov1beta2.AuthConfigStatusCondition{
+					{
+						Type:               authorinov1beta2.StatusConditionReady,
+						Status:             corev1.ConditionTrue,
+						Reason:             authorinov1beta2.StatusReasonReconciled,
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+				Summary: authorinov1beta2.AuthConfigStatusSummary{
+					Ready:         true,
+					HostsReady:    []string{"example.com"},
+					NumHostsReady: "1/1",
+				},
+			}
+
+			return envTest.Client.Status().Update(ctx, createdAuthConfig)
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) {
+			g.Expect(componentAnnotations(g, ctx)).To(HaveKeyWithValue(annotations.AuthConfigReady("").Key(), "true"))
+			g.Expect(componentAnnotations(g, ctx)).To(HaveKeyWithValue(annotations.AuthConfigHostsReady("").Key(), "example.com"))
+			g.Expect(componentAnnotations(g, ctx)).ToNot(HaveKey(annotations.AuthConfigStatus("").Key()))
+			g.Expect(componentAnnotations(g, ctx)).To(HaveKeyWithValue(annotations.RoutingAddressesPublic("").Key(), "test-component.example.com"))
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should preserve labels added to AuthConfig by other parties while enforcing its spec", func(ctx context.Context) {
+		// given
+		authConfigKey := types.NamespacedName{Name: resourceName, Namespace: testNamespaceName}
+
+		Eventually(func(ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			if err := envTest.Client.Get(ctx, authConfigKey, createdAuthConfig); err != nil {
+				return err
+			}
+
+			createdAuthConfig.Labels["example.com/added-by"] = "another-tool"
+			createdAuthConfig.Spec.Hosts = []string{"modified.example.com"}
+
+			return envTest.Client.Update(ctx, createdAuthConfig)
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) error {
+			reconciledAuthConfig := &authorinov1beta2.AuthConfig{}
+			if err := envTest.Client.Get(ctx, authConfigKey, reconciledAuthConfig); err != nil {
+				return err
+			}
+
+			g.Expect(reconciledAuthConfig.Spec.Hosts).To(ConsistOf("example.com"))
+			g.Expect(reconciledAuthConfig.Labels).To(HaveKeyWithValue("example.com/added-by", "another-tool"))
+			g.Expect(reconciledAuthConfig.Labels).To(HaveKeyWithValue("security.opendatahub.io/authorization-group", "default"))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	// Using k8s envtest we are not able to test actual garbage collection of resources. [1]
+	// Therefore, we ensure we have correct ownerRefs set.
+	//
+	// [1] https://book.kubebuilder.io/reference/envtest#testing-considerations
+	It("should have ownerReference on all created auth resources", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdResource := &istiosecurityv1beta1.AuthorizationPolicy{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdResource)
+
+			if err != nil {
+				return err
+			}
+
+			ctrl := true
+			expectedOwnerRef := metav1.OwnerReference{
+				APIVersion: createdComponent.GetAPIVersion(),
+				Kind:       createdComponent.GetKind(),
+				Name:       createdComponent.GetName(),
+				UID:        createdComponent.GetUID(),
+				Controller: &ctrl,
+			}
+
+			g.Expect(createdResource.OwnerReferences).To(ContainElement(expectedOwnerRef))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+})
+
+var _ = Describe("Checking AuthorizationPolicy rules for protected ports", test.EnvTest(), func() {
+	var (
+		resourceName      string
+		testNamespaceName string
+		testNamespace     *corev1.Namespace
+		createdComponent  *unstructured.Unstructured
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		resourceName = "test-component"
+		createdComponent, testNamespace = createComponent(ctx, "PortsComponent", resourceName)
+		testNamespaceName = testNamespace.Name
+	})
+
+	AfterEach(func() {
+		envTest.DeleteAll(createdComponent, testNamespace)
+	})
+
+	It("should exclude default unprotected paths from AuthorizationPolicy rules", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthPolicy := &istiosecurityv1beta1.AuthorizationPolicy{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthPolicy)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthPolicy.Spec.GetRules()).To(HaveLen(1))
+			operation := createdAuthPolicy.Spec.GetRules()[0].GetTo()[0].GetOperation()
+			g.Expect(operation.GetPorts()).To(ConsistOf("8080"))
+			g.Expect(operation.GetNotPaths()).To(ConsistOf("/healthz", "/debug/pprof/", "/metrics", "/wait-for-drain"))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should exclude paths defined using annotation from AuthorizationPolicy rules", func(ctx context.Context) {
+		// given
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent,
+				annotations.UnprotectedPaths(`[{"paths":["/healthz"]},{"paths":["/v2/health/ready"],"methods":["GET"]}]`),
+			)
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthPolicy := &istiosecurityv1beta1.AuthorizationPolicy{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthPolicy)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthPolicy.Spec.GetRules()).To(HaveLen(2))
+
+			allPathsOperation := createdAuthPolicy.Spec.GetRules()[0].GetTo()[0].GetOperation()
+			g.Expect(allPathsOperation.GetNotPaths()).To(ConsistOf("/healthz", "/v2/health/ready"))
+
+			methodScopedOperation := createdAuthPolicy.Spec.GetRules()[1].GetTo()[0].GetOperation()
+			g.Expect(methodScopedOperation.GetPorts()).To(ConsistOf("8080"))
+			g.Expect(methodScopedOperation.GetPaths()).To(ConsistOf("/v2/health/ready"))
+			g.Expect(methodScopedOperation.GetNotMethods()).To(ConsistOf("GET"))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+})
+
+var _ = Describe("Checking identity headers passed to the workload", test.EnvTest(), func() {
+	var (
+		resourceName      string
+		testNamespaceName string
+		testNamespace     *corev1.Namespace
+		createdComponent  *unstructured.Unstructured
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		resourceName = "test-component"
+		createdComponent, testNamespace = createComponent(ctx, "HeadersComponent", resourceName)
+		testNamespaceName = testNamespace.Name
+	})
+
+	AfterEach(func() {
+		envTest.DeleteAll(createdComponent, testNamespace)
+	})
+
+	It("should pass identity of the caller to the workload using headers", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthConfig)
+
+			if err != nil {
+				return err
+			}
+
+			// Identity headers defined in suite_test
+			g.Expect(createdAuthConfig.Spec.Response).ToNot(BeNil())
+			g.Expect(createdAuthConfig.Spec.Response.Success.Headers).To(HaveKey("x-forwarded-user"))
+			g.Expect(createdAuthConfig.Spec.Response.Success.Headers["x-forwarded-user"].Plain.Selector).
+				To(Equal("{auth.identity.username}"))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should authorize requests to unprotected paths carrying identity headers", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthPolicy := &istiosecurityv1beta1.AuthorizationPolicy{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthPolicy)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthPolicy.Spec.GetRules()).To(HaveLen(2))
+
+			// Identity headers defined in suite_test
+			identityHeadersRule := createdAuthPolicy.Spec.GetRules()[1]
+			g.Expect(identityHeadersRule.GetTo()[0].GetOperation().GetPorts()).To(ConsistOf("8080"))
+			g.Expect(identityHeadersRule.GetTo()[0].GetOperation().GetPaths()).To(ConsistOf("/healthz", "/debug/pprof/", "/metrics", "/wait-for-drain"))
+			g.Expect(identityHeadersRule.GetWhen()).To(ConsistOf(
+				HaveField("Key", "request.headers[x-forwarded-user]"),
+			))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+})
+
+var _ = Describe("Checking enforcement scope of authorization", test.EnvTest(), func() {
+	var (
+		resourceName      string
+		testNamespaceName string
+		testNamespace     *corev1.Namespace
+		createdComponent  *unstructured.Unstructured
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		resourceName = "test-component"
+		createdComponent, testNamespace = createComponent(ctx, "ScopedComponent", resourceName)
+		testNamespaceName = testNamespace.Name
+	})
+
+	AfterEach(func() {
+		envTest.DeleteAll(createdComponent, testNamespace)
+	})
+
+	It("should scope AuthorizationPolicy rules to exported hosts and callers outside of exempt namespaces", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthPolicy := &istiosecurityv1beta1.AuthorizationPolicy{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthPolicy)
+
+			if err != nil {
+				return err
+			}
+
+			// EnforcementScope and identity headers defined in suite_test
+			g.Expect(createdAuthPolicy.Spec.GetRules()).To(HaveLen(2))
+
+			enforcementRule := createdAuthPolicy.Spec.GetRules()[0]
+			g.Expect(enforcementRule.GetTo()[0].GetOperation().GetHosts()).To(ConsistOf("example.com", "example.com:*"))
+			g.Expect(enforcementRule.GetFrom()[0].GetSource().GetNotNamespaces()).To(ConsistOf("monitoring"))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should authorize requests carrying identity headers from callers in exempt namespaces", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthPolicy := &istiosecurityv1beta1.AuthorizationPolicy{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthPolicy)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthPolicy.Spec.GetRules()).To(HaveLen(2))
+
+			// Matches any request to the port carrying the header, so that callers from "monitoring" namespace
+			// or using hosts which are not exported cannot pass the header to the workload
+			identityHeadersRule := createdAuthPolicy.Spec.GetRules()[1]
+			g.Expect(identityHeadersRule.GetFrom()).To(BeEmpty())
+			g.Expect(identityHeadersRule.GetTo()[0].GetOperation().GetPorts()).To(ConsistOf("8080"))
+			g.Expect(identityHeadersRule.GetTo()[0].GetOperation().GetHosts()).To(BeEmpty())
+			g.Expect(identityHeadersRule.GetTo()[0].GetOperation().GetPaths()).To(BeEmpty())
+			g.Expect(identityHeadersRule.GetWhen()).To(ConsistOf(
+				HaveField("Key", "request.headers[x-forwarded-user]"),
+			))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+})
+
+var _ = Describe("Checking PeerAuthentication management", test.EnvTest(), func() {
+	var (
+		resourceName      string
+		testNamespaceName string
+		testNamespace     *corev1.Namespace
+		createdComponent  *unstructured.Unstructured
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		resourceName = "test-component"
+		createdComponent, testNamespace = createComponent(ctx, "MeshComponent", resourceName)
+		testNamespaceName = testNamespace.Name
+	})
+
+	AfterEach(func() {
+		envTest.DeleteAll(createdComponent, testNamespace)
+	})
+
+	It("should create a PeerAuthentication with per-port mTLS modes when enabled for the ProtectedResource", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdPeerAuthn := &istiosecurityv1beta1.PeerAuthentication{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdPeerAuthn)
+
+			if err != nil {
+				return err
+			}
+
+			// WorkloadSelector expression and mTLS modes defined in suite_test
+			g.Expect(createdPeerAuthn.Spec.GetSelector().GetMatchLabels()).To(HaveKeyWithValue("component", resourceName))
+			g.Expect(createdPeerAuthn.Spec.GetMtls().GetMode()).To(Equal(v1beta1.PeerAuthentication_MutualTLS_STRICT))
+			g.Expect(createdPeerAuthn.Spec.GetPortLevelMtls()).To(HaveKeyWithValue(uint32(9090),
+				HaveField("Mode", v1beta1.PeerAuthentication_MutualTLS_PERMISSIVE)))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+})
+
+var _ = Describe("Checking OIDC authentication", test.EnvTest(), func() {
+	var (
+		resourceName      string
+		testNamespaceName string
+		testNamespace     *corev1.Namespace
+		createdComponent  *unstructured.Unstructured
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		resourceName = "test-component"
+		createdComponent, testNamespace = createComponent(ctx, "OIDCComponent", resourceName)
+		testNamespaceName = testNamespace.Name
+	})
+
+	AfterEach(func() {
+		envTest.DeleteAll(createdComponent, testNamespace)
+	})
+
+	It("should create an OIDC AuthConfig resource with required claims when requested using annotations", func(ctx context.Context) {
+		_, errCreate := controllerutil.CreateOrUpdate(ctx, envTest.Client, createdComponent, func() error {
+			metadata.ApplyMetaOptions(createdComponent,
+				annotations.AuthEnabled("true"),
+				annotations.AuthType("oidc"),
+				annotations.RequiredClaims("groups~=data-science"),
+			)
+
+			return nil
+		})
+		Expect(errCreate).ToNot(HaveOccurred())
+
+		Eventually(func(g Gomega, ctx context.Context) error {
+			createdAuthConfig := &authorinov1beta2.AuthConfig{}
+			err := envTest.Client.Get(ctx, types.NamespacedName{
+				Name:      resourceName,
+				Namespace: testNamespaceName,
+			}, createdAuthConfig)
+
+			if err != nil {
+				return err
+			}
+
+			g.Expect(createdAuthConfig).To(HaveAuthenticationMethod("oidc-user"))
+			g.Expect(createdAuthConfig).NotTo(HaveAuthenticationMethod("kubernetes-user"))
+			g.Expect(createdAuthConfig.Spec.Authentication["oidc-user"].Jwt.IssuerUrl).To(Equal("https://sso.example.com/realms/opendatahub"))
+			g.Expect(createdAuthConfig.Spec.Authorization).To(HaveKey("required-claims"))
+
+			return nil
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+})
+
+func componentResource(kind, name, namespace string) []byte {
+	return []byte(fmt.Sprintf(watchedCR, name, namespace, kind))
+}
+
+// createComponent creates a namespace with a random name holding a new instance of the given kind of test component.
+func createComponent(ctx context.Context, kind, name string) (*unstructured.Unstructured, *corev1.Namespace) {
+	namespace := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace" + utilrand.String(7),
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, envTest.Client, namespace, func() error {
+		return nil
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	component, errCreate := test.CreateResource(ctx, envTest.Client, componentResource(kind, name, namespace.Name))
+	Expect(errCreate).ToNot(HaveOccurred())
+
+	return component, namespace
 }
@@ -0,0 +1,89 @@
+package authzctrl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opendatahub-io/odh-platform/pkg/logging"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
+	"github.com/opendatahub-io/odh-platform/pkg/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// isOptedOut checks if the component explicitly disabled authorization using annotation.
+func isOptedOut(target *unstructured.Unstructured) bool {
+	value, found := target.GetAnnotations()[annotations.AuthDisabled().Key()]
+
+	return found && strings.EqualFold(value, annotations.AuthDisabled().Value())
+}
+
+// removeAuthResources deletes resources created for the target by the configured authorization provider.
+// Only resources controlled by the target are removed.
+func (r *Controller) removeAuthResources(ctx context.Context, target *unstructured.Unstructured) error {
+	metadata.ApplyMetaOptions(target, removeAuthConfigStatus()...)
+
+	var errs []error
+
+	for _, resource := range r.ownedResources() {
+		if err := r.deleteOwnedResource(ctx, target, resource); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Controller) deleteOwnedResource(ctx context.Context, target *unstructured.Unstructured, resource client.Object) error {
+	if errGet := r.Get(ctx, types.NamespacedName{Name: target.GetName(), Namespace: target.GetNamespace()}, resource); errGet != nil {
+		if client.IgnoreNotFound(errGet) == nil {
+			r.recordRemoved(target, resource)
+
+			return nil
+		}
+
+		return fmt.Errorf("unable to fetch %T: %w", resource, errGet)
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues(r.resourceValues(resource)...)
+
+	if !metav1.IsControlledBy(resource, target) {
+		log.Info("skipping removal of resource not controlled by the component")
+
+		return nil
+	}
+
+	if errDelete := r.Delete(ctx, resource, client.Preconditions{UID: ptr.To(resource.GetUID())}); client.IgnoreNotFound(errDelete) != nil {
+		return fmt.Errorf("unable to delete %T: %w", resource, errDelete)
+	}
+
+	log.V(1).Info("removed resource")
+	r.recordRemoved(target, resource)
+
+	return nil
+}
+
+// resourceValues returns key-value pairs identifying the typed resource, which does not carry its GVK.
+func (r *Controller) resourceValues(resource client.Object) []any {
+	gvk, errGVK := apiutil.GVKForObject(resource, r.Scheme())
+	if errGVK != nil {
+		gvk.Kind = fmt.Sprintf("%T", resource)
+	}
+
+	return logging.Resource(gvk, resource)
+}
+
+// recordRemoved records that the resource is no longer managed for the target.
+func (r *Controller) recordRemoved(target *unstructured.Unstructured, resource client.Object) {
+	if gvk, errGVK := apiutil.GVKForObject(resource, r.Scheme()); errGVK == nil {
+		metrics.SetManagedResources(metrics.Authorization, target, gvk.GroupKind(), 0)
+	}
+}
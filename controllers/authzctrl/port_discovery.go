@@ -0,0 +1,43 @@
+package authzctrl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resolvePorts returns ports of the workload subject to authorization. Unless PortDiscovery is enabled for the
+// ProtectedResource, statically defined Ports are used, which then have to be numbers.
+func (r *Controller) resolvePorts(ctx context.Context, target *unstructured.Unstructured, workloadSelector map[string]string) ([]string, error) {
+	if !r.protectedResource.PortDiscovery {
+		for _, port := range r.protectedResource.Ports {
+			if _, errConv := strconv.ParseUint(port, 10, 32); errConv != nil {
+				return nil, fmt.Errorf("port %q is not a number, named ports require port discovery to be enabled", port)
+			}
+		}
+
+		return r.protectedResource.Ports, nil
+	}
+
+	services := &corev1.ServiceList{}
+	if errList := r.Client.List(ctx, services, client.InNamespace(target.GetNamespace())); errList != nil {
+		return nil, fmt.Errorf("could not list services to discover ports: %w", errList)
+	}
+
+	ports, unresolved := r.protectedResource.DiscoverPorts(platform.ServicesSelecting(services.Items, workloadSelector))
+	for _, portRef := range unresolved {
+		r.recorder.Eventf(target, corev1.EventTypeWarning, "UnresolvedTargetPort",
+			"service port %s refers to named target port, which cannot be resolved for authorization", portRef)
+	}
+
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("no ports discovered from services selecting workload %v", workloadSelector)
+	}
+
+	return ports, nil
+}
@@ -2,19 +2,21 @@ package authzctrl
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"reflect"
+	"maps"
+	"strconv"
 	"strings"
 
 	authorinov1beta2 "github.com/kuadrant/authorino/api/v1beta2"
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata/labels"
-	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *Controller) reconcileAuthConfig(ctx context.Context, target *unstructured.Unstructured) error {
@@ -23,57 +25,37 @@ func (r *Controller) reconcileAuthConfig(ctx context.Context, target *unstructur
 		return err
 	}
 
-	templ, err := r.createAuthConfigTemplate(ctx, target)
+	authType, err := r.typeDetector.Detect(ctx, target)
 	if err != nil {
-		return err
+		return fmt.Errorf("could not detect authtype: %w", err)
 	}
 
-	desired, err := createAuthConfig(templ, hosts, r.config.Label, target)
+	templ, err := r.createAuthConfigTemplate(ctx, authType, target)
 	if err != nil {
-		return fmt.Errorf("could not create destired AuthConfig: %w", err)
+		return err
 	}
 
-	found := &authorinov1beta2.AuthConfig{}
-	justCreated := false
+	if errClaims := r.applyRequiredClaims(&templ, authType, target); errClaims != nil {
+		return errClaims
+	}
 
-	err = r.Get(ctx, types.NamespacedName{
-		Name:      desired.Name,
-		Namespace: desired.Namespace,
-	}, found)
-	if err != nil {
-		if k8serr.IsNotFound(err) {
-			errCreate := r.Create(ctx, desired)
-			if client.IgnoreAlreadyExists(errCreate) != nil {
-				return fmt.Errorf("unable to create AuthConfig: %w", errCreate)
-			}
-
-			justCreated = true
-		} else {
-			return fmt.Errorf("unable to fetch AuthConfig: %w", err)
-		}
+	if errAllowList := r.applyIdentityAllowList(&templ, authType, target); errAllowList != nil {
+		return errAllowList
 	}
 
-	// Reconcile the Authorino AuthConfig if it has been manually modified
-	if !justCreated && !CompareAuthConfigs(desired, found) {
-		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			if err := r.Get(ctx, types.NamespacedName{
-				Name:      desired.Name,
-				Namespace: desired.Namespace,
-			}, found); err != nil {
-				return fmt.Errorf("failed getting AuthConfig %s in namespace %s: %w", desired.Name, desired.Namespace, err)
-			}
+	r.applyIdentityHeaders(&templ)
 
-			found.Spec = *desired.Spec.DeepCopy()
-			found.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	if errCache := r.applyCaching(&templ, target); errCache != nil {
+		return errCache
+	}
 
-			if errUpdate := r.Update(ctx, found); errUpdate != nil {
-				return fmt.Errorf("failed updating AuthConfig: %w", errUpdate)
-			}
+	desired, err := createAuthConfig(templ, hosts, r.config.Label, target)
+	if err != nil {
+		return fmt.Errorf("could not create destired AuthConfig: %w", err)
+	}
 
-			return nil
-		}); err != nil {
-			return fmt.Errorf("unable to reconcile the Authorino AuthConfig: %w", err)
-		}
+	if errApply := r.apply(ctx, target, desired); errApply != nil {
+		return fmt.Errorf("unable to reconcile the Authorino AuthConfig: %w", errApply)
 	}
 
 	return nil
@@ -88,12 +70,12 @@ func createAuthConfig(authConfigTpl authorinov1beta2.AuthConfig, hosts []string,
 		authConfigTpl.Labels = map[string]string{}
 	}
 
-	keyValue := strings.Split(labelKV, "=")
-	if len(keyValue) != 2 {
-		return nil, fmt.Errorf("expected authorino label to be in key=value format, got [%s]", labelKV)
+	labelKey, labelValue, errLabel := splitAuthorinoLabel(labelKV)
+	if errLabel != nil {
+		return nil, errLabel
 	}
 
-	authConfigTpl.Labels[keyValue[0]] = keyValue[1]
+	authConfigTpl.Labels[labelKey] = labelValue
 
 	metadata.ApplyMetaOptions(&authConfigTpl, labels.AppendStandardLabelsFrom(target))
 
@@ -107,30 +89,240 @@ func createAuthConfig(authConfigTpl authorinov1beta2.AuthConfig, hosts []string,
 	return &authConfigTpl, nil
 }
 
-func CompareAuthConfigs(m1, m2 *authorinov1beta2.AuthConfig) bool {
-	return reflect.DeepEqual(m1.ObjectMeta.Labels, m2.ObjectMeta.Labels) &&
-		reflect.DeepEqual(m1.Spec, m2.Spec)
+func splitAuthorinoLabel(labelKV string) (string, string, error) {
+	keyValue := strings.Split(labelKV, "=")
+	if len(keyValue) != 2 {
+		return "", "", fmt.Errorf("expected authorino label to be in key=value format, got [%s]", labelKV)
+	}
+
+	return keyValue[0], keyValue[1], nil
 }
 
-func (r *Controller) createAuthConfigTemplate(ctx context.Context, target *unstructured.Unstructured) (authorinov1beta2.AuthConfig, error) {
-	authType, err := r.typeDetector.Detect(ctx, target)
-	if err != nil {
-		return authorinov1beta2.AuthConfig{}, fmt.Errorf("could not detect authtype: %w", err)
+func (r *Controller) createAuthConfigTemplate(ctx context.Context, authType authorization.AuthType,
+	target *unstructured.Unstructured) (authorinov1beta2.AuthConfig, error) {
+	if authType == authorization.OIDC && r.config.OIDC.IssuerURL == "" {
+		return authorinov1beta2.AuthConfig{}, errors.New("OIDC authentication requested, but OIDC issuer URL is not configured for the platform")
 	}
 
 	templateData := map[string]any{
-		"Namespace": target.GetNamespace(),
-		"Audiences": r.config.Audiences,
+		"Namespace":   target.GetNamespace(),
+		"Name":        target.GetName(),
+		"Group":       r.protectedResource.ResourceReference.Group,
+		"Resource":    r.protectedResource.ResourceReference.Resources,
+		"Verb":        r.protectedResource.AccessCheck.GetVerb(),
+		"SubResource": r.protectedResource.AccessCheck.SubResource,
+		"Audiences":   r.config.Audiences,
+		"OIDC":        r.config.OIDC,
+		"APIKeySelector": labels.MatchingLabels(
+			labels.APIKey("true"),
+			labels.OwnerKind(target.GetKind()),
+			labels.OwnerName(target.GetName()),
+		),
 	}
 
 	templ, err := r.templateLoader.Load(ctx, authType, types.NamespacedName{Namespace: target.GetNamespace(), Name: target.GetName()}, templateData)
 	if err != nil {
+		var errTemplate *authorization.InvalidTemplateError
+		if errors.As(err, &errTemplate) {
+			r.recorder.Event(target, corev1.EventTypeWarning, "InvalidAuthConfigTemplate", errTemplate.Error())
+		}
+
 		return authorinov1beta2.AuthConfig{}, fmt.Errorf("could not load template %s: %w", authType, err)
 	}
 
 	return templ, nil
 }
 
+// applyRequiredClaims adds authorization rule enforcing claims defined using annotation on the target resource.
+// Claims are only meaningful for tokens issued by OIDC provider. For any other AuthType the claims cannot be enforced,
+// and the AuthConfig is not reconciled rather than silently granting access without them.
+func (r *Controller) applyRequiredClaims(authConfig *authorinov1beta2.AuthConfig, authType authorization.AuthType,
+	target *unstructured.Unstructured) error {
+	requiredClaims, found := target.GetAnnotations()[annotations.RequiredClaims("").Key()]
+	if !found {
+		return nil
+	}
+
+	if authType != authorization.OIDC {
+		errAuthType := fmt.Errorf("%s annotation can only be enforced for %q authentication, but %q is used",
+			annotations.RequiredClaims("").Key(), authorization.OIDC, authType)
+		r.recorder.Event(target, corev1.EventTypeWarning, "UnsupportedRequiredClaims", errAuthType.Error())
+
+		return errAuthType
+	}
+
+	requirements, errParse := authorization.ParseClaimRequirements(requiredClaims)
+	if errParse != nil {
+		r.recorder.Event(target, corev1.EventTypeWarning, "InvalidRequiredClaims", errParse.Error())
+
+		return fmt.Errorf("could not parse %s annotation: %w", annotations.RequiredClaims("").Key(), errParse)
+	}
+
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	if authConfig.Spec.Authorization == nil {
+		authConfig.Spec.Authorization = map[string]authorinov1beta2.AuthorizationSpec{}
+	}
+
+	authConfig.Spec.Authorization["required-claims"] = authorization.ClaimRequirementsRule(requirements)
+
+	return nil
+}
+
+// applyIdentityAllowList grants access to groups and users defined using annotations on the target resource.
+// It relies on "username" and "groups" of the authenticated identity. For anonymous and API key access there is no such
+// identity, and the AuthConfig is not reconciled rather than silently granting access to every caller.
+// When the AuthConfig performs SubjectAccessReview, it is skipped for allowed identities, so that access is granted
+// either by Kubernetes RBAC or by the allow-list. Otherwise, only identities from the allow-list are authorized.
+func (r *Controller) applyIdentityAllowList(authConfig *authorinov1beta2.AuthConfig, authType authorization.AuthType,
+	target *unstructured.Unstructured) error {
+	allowList := identityAllowList(target)
+	if allowList.IsEmpty() {
+		return nil
+	}
+
+	if !hasUserIdentity(authConfig) {
+		errAuthType := fmt.Errorf("%s and %s annotations can only be enforced for authentication providing user identity, but %q is used",
+			annotations.AllowedGroups("").Key(), annotations.AllowedUsers("").Key(), authType)
+		r.recorder.Event(target, corev1.EventTypeWarning, "UnsupportedIdentityAllowList", errAuthType.Error())
+
+		return errAuthType
+	}
+
+	accessReviewed := false
+
+	for name, authz := range authConfig.Spec.Authorization {
+		if authz.KubernetesSubjectAccessReview == nil {
+			continue
+		}
+
+		authz.Conditions = append(authz.Conditions, authorization.NotInAllowListConditions(allowList)...)
+		authConfig.Spec.Authorization[name] = authz
+		accessReviewed = true
+	}
+
+	if accessReviewed {
+		return nil
+	}
+
+	if authConfig.Spec.Authorization == nil {
+		authConfig.Spec.Authorization = map[string]authorinov1beta2.AuthorizationSpec{}
+	}
+
+	authConfig.Spec.Authorization["allowed-identities"] = authorization.AllowListRule(allowList)
+
+	return nil
+}
+
+// identityAllowList returns groups and users allowed using annotations on the target resource.
+func identityAllowList(target *unstructured.Unstructured) authorization.IdentityAllowList {
+	return authorization.ParseIdentityAllowList(
+		target.GetAnnotations()[annotations.AllowedGroups("").Key()],
+		target.GetAnnotations()[annotations.AllowedUsers("").Key()],
+	)
+}
+
+// applyIdentityHeaders passes attributes of the authenticated identity to the workload using request headers.
+// Headers defined in the template are preserved, unless they are overridden by the identity headers.
+func (r *Controller) applyIdentityHeaders(authConfig *authorinov1beta2.AuthConfig) {
+	identityHeaders := r.protectedResource.IdentityHeaders
+	if len(identityHeaders) == 0 {
+		return
+	}
+
+	if authConfig.Spec.Response == nil {
+		authConfig.Spec.Response = &authorinov1beta2.ResponseSpec{}
+	}
+
+	if authConfig.Spec.Response.Success.Headers == nil {
+		authConfig.Spec.Response.Success.Headers = map[string]authorinov1beta2.HeaderSuccessResponseSpec{}
+	}
+
+	maps.Copy(authConfig.Spec.Response.Success.Headers, authorization.IdentityHeaders(identityHeaders))
+}
+
+// applyCaching enables caching of TokenReview and SubjectAccessReview results, so that not every request results in
+// calls to Kubernetes API. Platform defaults can be overridden using annotations on the target resource.
+// Evaluators with caching defined in the template are left untouched.
+func (r *Controller) applyCaching(authConfig *authorinov1beta2.AuthConfig, target *unstructured.Unstructured) error {
+	cacheConfig, errResolve := r.resolveCacheConfig(target)
+	if errResolve != nil {
+		return errResolve
+	}
+
+	if !cacheConfig.Enabled() {
+		return nil
+	}
+
+	cache := &authorinov1beta2.EvaluatorCaching{
+		Key: authorinov1beta2.ValueOrSelector{Selector: cacheConfig.Key},
+		TTL: cacheConfig.TTL,
+	}
+
+	for name, authn := range authConfig.Spec.Authentication {
+		if authn.KubernetesTokenReview != nil && authn.Cache == nil {
+			authn.Cache = cache
+			authConfig.Spec.Authentication[name] = authn
+		}
+	}
+
+	for name, authz := range authConfig.Spec.Authorization {
+		if authz.KubernetesSubjectAccessReview != nil && authz.Cache == nil {
+			authz.Cache = cache
+			authConfig.Spec.Authorization[name] = authz
+		}
+	}
+
+	return nil
+}
+
+// resolveCacheConfig returns the platform cache configuration overridden using annotations. As anyone allowed to edit
+// the resource can set them, the key can only be one known to identify the caller, so that cached results are never
+// shared between different identities.
+func (r *Controller) resolveCacheConfig(target *unstructured.Unstructured) (authorization.CacheConfig, error) {
+	cacheConfig := r.config.Cache
+
+	if key, found := target.GetAnnotations()[annotations.AuthCacheKey("").Key()]; found {
+		if key != authorization.DefaultCacheKey && key != r.config.Cache.Key {
+			errKey := fmt.Errorf("%s annotation can only be set to %q or %q, got [%s]",
+				annotations.AuthCacheKey("").Key(), authorization.DefaultCacheKey, r.config.Cache.Key, key)
+			r.recorder.Event(target, corev1.EventTypeWarning, "InvalidAuthCacheKey", errKey.Error())
+
+			return authorization.CacheConfig{}, errKey
+		}
+
+		cacheConfig.Key = key
+	}
+
+	if ttl, found := target.GetAnnotations()[annotations.AuthCacheTTL("").Key()]; found {
+		parsedTTL, errParse := strconv.Atoi(ttl)
+		if errParse != nil || parsedTTL < 0 {
+			r.recorder.Eventf(target, corev1.EventTypeWarning, "InvalidAuthCacheTTL",
+				"expected non-negative number of seconds in %s annotation, got [%s]", annotations.AuthCacheTTL("").Key(), ttl)
+
+			return authorization.CacheConfig{}, fmt.Errorf("expected non-negative number of seconds in %s annotation, got [%s]",
+				annotations.AuthCacheTTL("").Key(), ttl)
+		}
+
+		cacheConfig.TTL = parsedTTL
+	}
+
+	return cacheConfig, nil
+}
+
+// hasUserIdentity checks if the AuthConfig authenticates users, i.e. the resolved identity has username and groups.
+func hasUserIdentity(authConfig *authorinov1beta2.AuthConfig) bool {
+	for _, authentication := range authConfig.Spec.Authentication {
+		if authentication.KubernetesTokenReview != nil || authentication.Jwt != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r *Controller) extractHosts(target *unstructured.Unstructured) ([]string, error) {
 	hosts, err := r.hostExtractor(target)
 	if err != nil {
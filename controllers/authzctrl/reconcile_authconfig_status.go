@@ -0,0 +1,108 @@
+package authzctrl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	authorinov1beta2 "github.com/kuadrant/authorino/api/v1beta2"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const reasonNotReconciled = "NotReconciled"
+
+// reportAuthConfigStatus propagates readiness of the AuthConfig created for the target back to it using annotations.
+// Hosts which cannot be linked because they are already linked to other AuthConfigs are reported as Warning events.
+func (r *Controller) reportAuthConfigStatus(ctx context.Context, target *unstructured.Unstructured) error {
+	authConfig := &authorinov1beta2.AuthConfig{}
+	if errGet := r.Get(ctx, types.NamespacedName{Name: target.GetName(), Namespace: target.GetNamespace()}, authConfig); errGet != nil {
+		if k8serr.IsNotFound(errGet) {
+			metadata.ApplyMetaOptions(target, removeAuthConfigStatus()...)
+
+			return nil
+		}
+
+		return fmt.Errorf("unable to fetch AuthConfig: %w", errGet)
+	}
+
+	ready, reason, message := authConfigReadiness(authConfig)
+
+	metaOptions := []metadata.Option{
+		annotations.AuthConfigReady(strconv.FormatBool(ready)),
+		annotations.AuthConfigHostsReady(strings.Join(authConfig.Status.Summary.HostsReady, ";")),
+	}
+
+	if ready {
+		metaOptions = append(metaOptions, annotations.Remove(annotations.AuthConfigStatus("")))
+	} else {
+		metaOptions = append(metaOptions, annotations.AuthConfigStatus(reason+": "+message))
+	}
+
+	metadata.ApplyMetaOptions(target, metaOptions...)
+
+	if reason == authorinov1beta2.StatusReasonHostsNotLinked {
+		return r.reportHostConflicts(ctx, target, authConfig)
+	}
+
+	return nil
+}
+
+// reportHostConflicts emits Warning event for each host of the AuthConfig which is already linked to another AuthConfig
+// handled by the same Authorino instance.
+func (r *Controller) reportHostConflicts(ctx context.Context, target *unstructured.Unstructured, authConfig *authorinov1beta2.AuthConfig) error {
+	labelKey, labelValue, errLabel := splitAuthorinoLabel(r.config.Label)
+	if errLabel != nil {
+		return errLabel
+	}
+
+	authConfigs := &authorinov1beta2.AuthConfigList{}
+	if errList := r.List(ctx, authConfigs, client.MatchingLabels{labelKey: labelValue}); errList != nil {
+		return fmt.Errorf("unable to list AuthConfigs: %w", errList)
+	}
+
+	for _, host := range authConfig.Spec.Hosts {
+		if slices.Contains(authConfig.Status.Summary.HostsReady, host) {
+			continue
+		}
+
+		for i := range authConfigs.Items {
+			other := &authConfigs.Items[i]
+			if other.GetUID() == authConfig.GetUID() || !slices.Contains(other.Status.Summary.HostsReady, host) {
+				continue
+			}
+
+			r.recorder.Eventf(target, corev1.EventTypeWarning, "AuthConfigHostConflict",
+				"host %s is already linked to AuthConfig %s/%s", host, other.GetNamespace(), other.GetName())
+		}
+	}
+
+	return nil
+}
+
+// authConfigReadiness determines readiness of the AuthConfig based on its Ready condition.
+// AuthConfig without the condition has not been picked up by any Authorino instance yet.
+func authConfigReadiness(authConfig *authorinov1beta2.AuthConfig) (bool, string, string) {
+	for _, condition := range authConfig.Status.Conditions {
+		if condition.Type == authorinov1beta2.StatusConditionReady {
+			return condition.Status == corev1.ConditionTrue, condition.Reason, condition.Message
+		}
+	}
+
+	return false, reasonNotReconciled, "AuthConfig has not been reconciled by Authorino, verify its labels match Authorino instance selector"
+}
+
+func removeAuthConfigStatus() []metadata.Option {
+	return []metadata.Option{
+		annotations.Remove(annotations.AuthConfigReady("")),
+		annotations.Remove(annotations.AuthConfigHostsReady("")),
+		annotations.Remove(annotations.AuthConfigStatus("")),
+	}
+}
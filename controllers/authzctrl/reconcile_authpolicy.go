@@ -2,73 +2,93 @@ package authzctrl
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"reflect"
+	"slices"
+	"strings"
 
-	"github.com/opendatahub-io/odh-platform/pkg/config"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata/labels"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
 	"istio.io/api/security/v1beta1"
 	istiotypev1beta1 "istio.io/api/type/v1beta1"
 	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
-	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *Controller) reconcileAuthPolicy(ctx context.Context, target *unstructured.Unstructured) error {
-	resolvedSelectors, errResolve := config.ResolveSelectors(r.protectedResource.WorkloadSelector, target)
+	resolvedSelectors, errResolve := r.workloadSelector(ctx, target)
 	if errResolve != nil {
-		return fmt.Errorf("could not resolve WorkloadSelectors err: %w", errResolve)
-	}
-
-	desired := createAuthzPolicy(r.protectedResource.Ports, resolvedSelectors, r.config.ProviderName, target)
-	found := &istiosecurityv1beta1.AuthorizationPolicy{}
-	justCreated := false
-
-	typeName := types.NamespacedName{
-		Name:      desired.Name,
-		Namespace: desired.Namespace}
-	if errGet := r.Get(ctx, typeName, found); errGet != nil {
-		if k8serr.IsNotFound(errGet) {
-			errCreate := r.Create(ctx, desired)
-			if client.IgnoreAlreadyExists(errCreate) != nil {
-				return fmt.Errorf("unable to create AuthorizationPolicy: %w", errCreate)
-			}
-
-			justCreated = true
-		} else {
-			return fmt.Errorf("unable to fetch AuthorizationPolicy: %w", errGet)
-		}
+		return errResolve
 	}
 
-	// Reconcile the Istio AuthorizationPolicy if it has been manually modified
-	if !justCreated && !CompareAuthPolicies(desired, found) {
-		if errConflict := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			if errGet := r.Get(ctx, typeName, found); errGet != nil {
-				return fmt.Errorf("failed getting AuthorizationPolicy %s in namespace %s: %w", desired.Name, desired.Namespace, errGet)
-			}
+	ports, errPorts := r.resolvePorts(ctx, target, resolvedSelectors)
+	if errPorts != nil {
+		return errPorts
+	}
 
-			found.Spec = *desired.Spec.DeepCopy()
-			found.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	unprotectedPaths, errPaths := r.resolveUnprotectedPaths(target)
+	if errPaths != nil {
+		return errPaths
+	}
 
-			if errUpdate := r.Update(ctx, found); errUpdate != nil {
-				return fmt.Errorf("failed updating AuthorizationPolicy: %w", errUpdate)
-			}
+	identityHeaders := make([]string, 0, len(r.protectedResource.IdentityHeaders))
+	for header := range r.protectedResource.IdentityHeaders {
+		identityHeaders = append(identityHeaders, header)
+	}
 
-			return nil
-		}); errConflict != nil {
-			return fmt.Errorf("unable to reconcile the AuthorizationPolicy: %w", errConflict)
-		}
+	// Sorted to keep rules stable across reconciliations
+	slices.Sort(identityHeaders)
+
+	desired := createAuthzPolicy(ports, resolvedSelectors, unprotectedPaths, r.config.ProviderName, target)
+
+	if errScope := r.applyEnforcementScope(desired, target); errScope != nil {
+		return errScope
+	}
+
+	// Identity headers rules are not scoped, as requests outside the EnforcementScope would otherwise pass
+	// headers provided by the caller to the workload. Such requests are not authorized regardless of the path,
+	// so the rules have to match all of them.
+	identityHeadersPaths := unprotectedPaths
+	if r.protectedResource.EnforcementScope != nil {
+		identityHeadersPaths = []platform.PathExclusion{{}}
+	}
+
+	for _, port := range ports {
+		desired.Spec.Rules = append(desired.Spec.Rules, createIdentityHeadersRules(port, identityHeadersPaths, identityHeaders)...)
+	}
+
+	if errApply := r.apply(ctx, target, desired); errApply != nil {
+		return fmt.Errorf("unable to reconcile the AuthorizationPolicy: %w", errApply)
 	}
 
 	return nil
 }
 
-func createAuthzPolicy(ports []string, workloadSelector map[string]string, providerName string, target *unstructured.Unstructured) *istiosecurityv1beta1.AuthorizationPolicy {
+// resolveUnprotectedPaths returns path exclusions defined for the watched resource instance using annotation,
+// falling back to those defined for the ProtectedResource.
+func (r *Controller) resolveUnprotectedPaths(target *unstructured.Unstructured) ([]platform.PathExclusion, error) {
+	value, found := target.GetAnnotations()[annotations.UnprotectedPaths("").Key()]
+	if !found {
+		return r.protectedResource.GetUnprotectedPaths(), nil
+	}
+
+	var unprotectedPaths []platform.PathExclusion
+	if errParse := json.Unmarshal([]byte(value), &unprotectedPaths); errParse != nil {
+		r.recorder.Eventf(target, corev1.EventTypeWarning, "InvalidUnprotectedPaths",
+			"could not parse %s annotation: %v", annotations.UnprotectedPaths("").Key(), errParse)
+
+		return nil, fmt.Errorf("could not parse %s annotation: %w", annotations.UnprotectedPaths("").Key(), errParse)
+	}
+
+	return unprotectedPaths, nil
+}
+
+func createAuthzPolicy(ports []string, workloadSelector map[string]string, unprotectedPaths []platform.PathExclusion,
+	providerName string, target *unstructured.Unstructured) *istiosecurityv1beta1.AuthorizationPolicy {
 	policy := &istiosecurityv1beta1.AuthorizationPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        target.GetName(),
@@ -92,39 +112,132 @@ func createAuthzPolicy(ports []string, workloadSelector map[string]string, provi
 	}
 
 	for _, port := range ports {
-		rule := v1beta1.Rule{
+		policy.Spec.Rules = append(policy.Spec.Rules, createRules(port, unprotectedPaths)...)
+	}
+
+	metadata.ApplyMetaOptions(policy, labels.StandardLabelsFrom(target)...)
+
+	return policy
+}
+
+// createRules creates rules enforcing authorization for all requests to the given port except the unprotected ones.
+// As CUSTOM action applies when any of the rules matches, method-scoped exclusions are expressed as additional rules
+// matching requests to excluded paths performed using all the other methods.
+func createRules(port string, unprotectedPaths []platform.PathExclusion) []*v1beta1.Rule {
+	var notPaths []string
+
+	var methodScopedRules []*v1beta1.Rule
+
+	for _, exclusion := range unprotectedPaths {
+		notPaths = append(notPaths, exclusion.Paths...)
+
+		if len(exclusion.Methods) == 0 {
+			continue
+		}
+
+		methodScopedRules = append(methodScopedRules, &v1beta1.Rule{
 			To: []*v1beta1.Rule_To{
 				{
 					Operation: &v1beta1.Operation{
-						Ports: []string{port},
-						NotPaths: []string{ // TODO: part of AuthRule?
-							"/healthz",
-							"/debug/pprof/",
-							"/metrics",
-							"/wait-for-drain",
-						},
+						Ports:      []string{port},
+						Paths:      exclusion.Paths,
+						NotMethods: exclusion.Methods,
 					},
 				},
 			},
+		})
+	}
+
+	rule := &v1beta1.Rule{
+		To: []*v1beta1.Rule_To{
+			{
+				Operation: &v1beta1.Operation{
+					Ports:    []string{port},
+					NotPaths: notPaths,
+				},
+			},
+		},
+	}
+
+	return append([]*v1beta1.Rule{rule}, methodScopedRules...)
+}
+
+// createIdentityHeadersRules creates rules matching requests to the given paths which carry any of the identity headers.
+// Such requests are subject to authorization, so that the headers are overwritten with the authenticated identity
+// (or the request is rejected), ensuring values provided by the caller never reach the workload.
+func createIdentityHeadersRules(port string, unprotectedPaths []platform.PathExclusion, identityHeaders []string) []*v1beta1.Rule {
+	var rules []*v1beta1.Rule
+
+	for _, exclusion := range unprotectedPaths {
+		for _, header := range identityHeaders {
+			rules = append(rules, &v1beta1.Rule{
+				To: []*v1beta1.Rule_To{
+					{
+						Operation: &v1beta1.Operation{
+							Ports:   []string{port},
+							Paths:   exclusion.Paths,
+							Methods: exclusion.Methods,
+						},
+					},
+				},
+				When: []*v1beta1.Condition{
+					{
+						Key:    "request.headers[" + strings.ToLower(header) + "]",
+						Values: []string{"*"},
+					},
+				},
+			})
 		}
-		policy.Spec.Rules = append(policy.Spec.Rules, &rule)
 	}
 
-	metadata.ApplyMetaOptions(policy, labels.StandardLabelsFrom(target)...)
+	return rules
+}
 
-	return policy
+// applyEnforcementScope narrows down the rules of the policy to the EnforcementScope defined for the ProtectedResource.
+func (r *Controller) applyEnforcementScope(policy *istiosecurityv1beta1.AuthorizationPolicy, target *unstructured.Unstructured) error {
+	scope := r.protectedResource.EnforcementScope
+	if scope == nil {
+		return nil
+	}
+
+	var hosts []string
+
+	if scope.ExportedHostsOnly {
+		extractedHosts, errHosts := r.extractHosts(target)
+		if errHosts != nil {
+			return errHosts
+		}
+
+		for _, host := range extractedHosts {
+			// Host header includes the port when it is not the default one for the scheme
+			hosts = append(hosts, host, host+":*")
+		}
+	}
+
+	scopeRules(policy.Spec.GetRules(), hosts, scope.ExemptPrincipals, scope.ExemptNamespaces)
+
+	return nil
 }
 
-func CompareAuthPolicies(authzPolicy1, authzPolicy2 *istiosecurityv1beta1.AuthorizationPolicy) bool {
-	// .Spec contains MessageState from protobuf which has pragma.DoNotCopy (empty mutex slice)
-	// go vet complains about copying mutex when calling DeepEquals on passed variables, when it tries to access it.
-	// DeepCopy-ing solves this problem as it's using proto.Clone underneath. This implementation recreates mutex instead of
-	// directly copying.
-	// Alternatively we could break DeepEquals calls to individual .Spec exported fields, but that might mean ensuring we
-	// always compare all relevant fields when API changes.
-	authzSpec1 := authzPolicy1.Spec.DeepCopy()
-	authzSpec2 := authzPolicy2.Spec.DeepCopy()
-
-	return reflect.DeepEqual(authzPolicy1.ObjectMeta.Labels, authzPolicy2.ObjectMeta.Labels) &&
-		reflect.DeepEqual(authzSpec1, authzSpec2)
+// scopeRules limits rules to requests for the given hosts, made by callers other than the exempt ones.
+// Source constraints are added to existing ones, as all of them have to match for the rule to apply.
+func scopeRules(rules []*v1beta1.Rule, hosts, exemptPrincipals, exemptNamespaces []string) {
+	for _, rule := range rules {
+		for _, to := range rule.GetTo() {
+			to.Operation.Hosts = hosts
+		}
+
+		if len(exemptPrincipals) == 0 && len(exemptNamespaces) == 0 {
+			continue
+		}
+
+		if len(rule.From) == 0 {
+			rule.From = []*v1beta1.Rule_From{{Source: &v1beta1.Source{}}}
+		}
+
+		for _, from := range rule.From {
+			from.Source.NotPrincipals = exemptPrincipals
+			from.Source.NotNamespaces = exemptNamespaces
+		}
+	}
 }
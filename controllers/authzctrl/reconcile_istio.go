@@ -0,0 +1,290 @@
+package authzctrl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/labels"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"istio.io/api/security/v1beta1"
+	istiotypev1beta1 "istio.io/api/type/v1beta1"
+	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// reconcileRequestAuthentication ensures JWTs sent to the workload are verified by Istio. It is used by IstioProvider
+// in place of Authorino AuthConfig:
+//   - Anonymous does not require any token, so RequestAuthentication is removed.
+//   - UserDefined verifies Kubernetes service account tokens using the configured JWKS URI. As there is no TokenReview
+//     nor SubjectAccessReview available, opaque tokens (e.g. OpenShift user tokens) are not accepted and access checks
+//     are not performed.
+//   - OIDC verifies tokens issued by the platform OIDC provider.
+//   - APIKey is not supported.
+func (r *Controller) reconcileRequestAuthentication(ctx context.Context, target *unstructured.Unstructured) error {
+	authType, errDetect := r.typeDetector.Detect(ctx, target)
+	if errDetect != nil {
+		return fmt.Errorf("could not detect authtype: %w", errDetect)
+	}
+
+	if authType == authorization.Anonymous {
+		return r.deleteOwnedResource(ctx, target, &istiosecurityv1beta1.RequestAuthentication{})
+	}
+
+	jwtRule, errRule := r.createJWTRule(authType)
+	if errRule != nil {
+		r.recorder.Event(target, corev1.EventTypeWarning, "UnsupportedAuthType", errRule.Error())
+
+		return errRule
+	}
+
+	resolvedSelectors, errResolve := r.workloadSelector(ctx, target)
+	if errResolve != nil {
+		return errResolve
+	}
+
+	desired := createRequestAuthentication(jwtRule, resolvedSelectors, target)
+	if errApply := r.apply(ctx, target, desired); errApply != nil {
+		return fmt.Errorf("unable to reconcile the RequestAuthentication: %w", errApply)
+	}
+
+	return nil
+}
+
+// reconcileDenyPolicy ensures that requests to protected ports without a valid token, with a token missing
+// required claims, or identifying neither user nor group from the allow-list, are rejected.
+// Requests to unprotected paths are not affected.
+func (r *Controller) reconcileDenyPolicy(ctx context.Context, target *unstructured.Unstructured) error {
+	authType, errDetect := r.typeDetector.Detect(ctx, target)
+	if errDetect != nil {
+		return fmt.Errorf("could not detect authtype: %w", errDetect)
+	}
+
+	allowList, errAllowList := r.resolveIdentityAllowList(authType, target)
+	if errAllowList != nil {
+		return errAllowList
+	}
+
+	if authType == authorization.Anonymous {
+		return r.deleteOwnedResource(ctx, target, &istiosecurityv1beta1.AuthorizationPolicy{})
+	}
+
+	resolvedSelectors, errResolve := r.workloadSelector(ctx, target)
+	if errResolve != nil {
+		return errResolve
+	}
+
+	ports, errPorts := r.resolvePorts(ctx, target, resolvedSelectors)
+	if errPorts != nil {
+		return errPorts
+	}
+
+	unprotectedPaths, errPaths := r.resolveUnprotectedPaths(target)
+	if errPaths != nil {
+		return errPaths
+	}
+
+	requirements, errClaims := r.resolveRequiredClaims(authType, target)
+	if errClaims != nil {
+		return errClaims
+	}
+
+	desired := createDenyPolicy(ports, resolvedSelectors, unprotectedPaths, requirements, allowList, r.config.OIDC, target)
+
+	if errScope := r.applyEnforcementScope(desired, target); errScope != nil {
+		return errScope
+	}
+
+	if errApply := r.apply(ctx, target, desired); errApply != nil {
+		return fmt.Errorf("unable to reconcile the AuthorizationPolicy: %w", errApply)
+	}
+
+	return nil
+}
+
+func (r *Controller) createJWTRule(authType authorization.AuthType) (*v1beta1.JWTRule, error) {
+	switch authType {
+	case authorization.UserDefined:
+		// Service account issuer is usually not reachable from the mesh, nor exposes OpenID Connect discovery
+		// endpoint, so the key set has to be configured explicitly.
+		if r.config.ServiceAccountIssuer.JWKSURI == "" {
+			return nil, errors.New("service account token authentication requested, but JWKS URI of the service account issuer is not configured for the platform")
+		}
+
+		return &v1beta1.JWTRule{
+			Issuer:               r.config.ServiceAccountIssuer.IssuerURL,
+			JwksUri:              r.config.ServiceAccountIssuer.JWKSURI,
+			Audiences:            r.config.Audiences,
+			ForwardOriginalToken: true,
+		}, nil
+	case authorization.OIDC:
+		if r.config.OIDC.IssuerURL == "" {
+			return nil, errors.New("OIDC authentication requested, but OIDC issuer URL is not configured for the platform")
+		}
+
+		return &v1beta1.JWTRule{
+			Issuer:               r.config.OIDC.IssuerURL,
+			Audiences:            r.config.OIDC.Audiences,
+			ForwardOriginalToken: true,
+		}, nil
+	case authorization.Anonymous, authorization.APIKey:
+	}
+
+	return nil, fmt.Errorf("authentication type %q is not supported by %q authorization provider", authType, authorization.IstioProvider)
+}
+
+// resolveRequiredClaims parses claims required using annotation. As for Authorino provider, claims can only be
+// enforced for tokens issued by OIDC provider, for other auth types the resource fails to reconcile.
+func (r *Controller) resolveRequiredClaims(authType authorization.AuthType, target *unstructured.Unstructured) ([]authorization.ClaimRequirement, error) {
+	requiredClaims, found := target.GetAnnotations()[annotations.RequiredClaims("").Key()]
+	if !found {
+		return nil, nil
+	}
+
+	if authType != authorization.OIDC {
+		errUnsupported := fmt.Errorf("required claims can only be enforced for %q auth type, got %q", authorization.OIDC, authType)
+		r.recorder.Event(target, corev1.EventTypeWarning, "UnsupportedRequiredClaims", errUnsupported.Error())
+
+		return nil, errUnsupported
+	}
+
+	requirements, errParse := authorization.ParseClaimRequirements(requiredClaims)
+	if errParse != nil {
+		r.recorder.Event(target, corev1.EventTypeWarning, "InvalidRequiredClaims", errParse.Error())
+
+		return nil, fmt.Errorf("could not parse %s annotation: %w", annotations.RequiredClaims("").Key(), errParse)
+	}
+
+	return requirements, nil
+}
+
+// resolveIdentityAllowList returns groups and users allowed using annotations. Istio only knows claims of the verified
+// JWT, so as for required claims, the allow-list can only be enforced for tokens issued by OIDC provider, and for other
+// auth types the resource fails to reconcile.
+func (r *Controller) resolveIdentityAllowList(authType authorization.AuthType, target *unstructured.Unstructured) (authorization.IdentityAllowList, error) {
+	allowList := identityAllowList(target)
+	if allowList.IsEmpty() || authType == authorization.OIDC {
+		return allowList, nil
+	}
+
+	errUnsupported := fmt.Errorf("allowed groups and users can only be enforced for %q auth type, got %q", authorization.OIDC, authType)
+	r.recorder.Event(target, corev1.EventTypeWarning, "UnsupportedIdentityAllowList", errUnsupported.Error())
+
+	return authorization.IdentityAllowList{}, errUnsupported
+}
+
+func createRequestAuthentication(jwtRule *v1beta1.JWTRule, workloadSelector map[string]string,
+	target *unstructured.Unstructured) *istiosecurityv1beta1.RequestAuthentication {
+	requestAuthn := &istiosecurityv1beta1.RequestAuthentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.GetName(),
+			Namespace: target.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				targetToOwnerRef(target),
+			},
+		},
+		Spec: v1beta1.RequestAuthentication{
+			Selector: &istiotypev1beta1.WorkloadSelector{
+				MatchLabels: workloadSelector,
+			},
+			JwtRules: []*v1beta1.JWTRule{jwtRule},
+		},
+	}
+
+	metadata.ApplyMetaOptions(requestAuthn, labels.StandardLabelsFrom(target)...)
+
+	return requestAuthn
+}
+
+// createDenyPolicy creates DENY AuthorizationPolicy for requests to protected ports which are:
+//   - not authenticated, i.e. there is no verified JWT (request principal),
+//   - missing any of the required claims,
+//   - identifying neither user nor group from the allow-list.
+//
+// As DENY action applies when any of the rules matches, each requirement is expressed as a separate set of rules.
+// Conditions of a single rule have to be all met, so the allow-list is expressed as one rule matching callers
+// outside of both allowed users and groups.
+func createDenyPolicy(ports []string, workloadSelector map[string]string, unprotectedPaths []platform.PathExclusion,
+	requirements []authorization.ClaimRequirement, allowList authorization.IdentityAllowList, oidc authorization.OIDCConfig,
+	target *unstructured.Unstructured) *istiosecurityv1beta1.AuthorizationPolicy {
+	policy := &istiosecurityv1beta1.AuthorizationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.GetName(),
+			Namespace: target.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				targetToOwnerRef(target),
+			},
+		},
+		Spec: v1beta1.AuthorizationPolicy{
+			Selector: &istiotypev1beta1.WorkloadSelector{
+				MatchLabels: workloadSelector,
+			},
+			Action: v1beta1.AuthorizationPolicy_DENY,
+		},
+	}
+
+	for _, port := range ports {
+		for _, rule := range createRules(port, unprotectedPaths) {
+			rule.From = []*v1beta1.Rule_From{
+				{
+					Source: &v1beta1.Source{
+						NotRequestPrincipals: []string{"*"},
+					},
+				},
+			}
+			policy.Spec.Rules = append(policy.Spec.Rules, rule)
+		}
+
+		for _, requirement := range requirements {
+			for _, rule := range createRules(port, unprotectedPaths) {
+				rule.When = []*v1beta1.Condition{
+					{
+						Key:       authorization.ClaimConditionKey(requirement.Claim, oidc),
+						NotValues: requirement.Values,
+					},
+				}
+				policy.Spec.Rules = append(policy.Spec.Rules, rule)
+			}
+		}
+
+		if allowList.IsEmpty() {
+			continue
+		}
+
+		for _, rule := range createRules(port, unprotectedPaths) {
+			rule.When = notInAllowListConditions(allowList, oidc)
+			policy.Spec.Rules = append(policy.Spec.Rules, rule)
+		}
+	}
+
+	metadata.ApplyMetaOptions(policy, labels.StandardLabelsFrom(target)...)
+
+	return policy
+}
+
+// notInAllowListConditions creates conditions which are only met for JWTs with the username claim outside of allowed
+// users and none of the groups claim values in allowed groups.
+func notInAllowListConditions(allowList authorization.IdentityAllowList, oidc authorization.OIDCConfig) []*v1beta1.Condition {
+	var conditions []*v1beta1.Condition
+
+	if len(allowList.Users) > 0 {
+		conditions = append(conditions, &v1beta1.Condition{
+			Key:       authorization.ClaimConditionKey("username", oidc),
+			NotValues: allowList.Users,
+		})
+	}
+
+	if len(allowList.Groups) > 0 {
+		conditions = append(conditions, &v1beta1.Condition{
+			Key:       authorization.ClaimConditionKey("groups", oidc),
+			NotValues: allowList.Groups,
+		})
+	}
+
+	return conditions
+}
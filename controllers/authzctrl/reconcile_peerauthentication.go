@@ -0,0 +1,111 @@
+package authzctrl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/odh-platform/pkg/metadata"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/labels"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"istio.io/api/security/v1beta1"
+	istiotypev1beta1 "istio.io/api/type/v1beta1"
+	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// reconcilePeerAuthentication ensures mTLS modes defined for the ProtectedResource are applied to its workload.
+// When PeerAuthentication is not enabled for the ProtectedResource, the one previously created is removed.
+func (r *Controller) reconcilePeerAuthentication(ctx context.Context, target *unstructured.Unstructured) error {
+	if r.protectedResource.PeerAuthentication == nil {
+		return r.deleteOwnedResource(ctx, target, &istiosecurityv1beta1.PeerAuthentication{})
+	}
+
+	resolvedSelectors, errResolve := r.workloadSelector(ctx, target)
+	if errResolve != nil {
+		return errResolve
+	}
+
+	desired, errCreate := createPeerAuthentication(*r.protectedResource.PeerAuthentication, resolvedSelectors, target)
+	if errCreate != nil {
+		return fmt.Errorf("could not create desired PeerAuthentication: %w", errCreate)
+	}
+
+	if errApply := r.apply(ctx, target, desired); errApply != nil {
+		return fmt.Errorf("unable to reconcile the PeerAuthentication: %w", errApply)
+	}
+
+	return nil
+}
+
+func createPeerAuthentication(mtls platform.MTLSConfig, workloadSelector map[string]string,
+	target *unstructured.Unstructured) (*istiosecurityv1beta1.PeerAuthentication, error) {
+	mode, errMode := parseMTLSMode(mtls.Mode)
+	if errMode != nil {
+		return nil, errMode
+	}
+
+	peerAuthn := &istiosecurityv1beta1.PeerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.GetName(),
+			Namespace: target.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				targetToOwnerRef(target),
+			},
+		},
+		Spec: v1beta1.PeerAuthentication{
+			Selector: &istiotypev1beta1.WorkloadSelector{
+				MatchLabels: workloadSelector,
+			},
+			Mtls: &v1beta1.PeerAuthentication_MutualTLS{Mode: mode},
+		},
+	}
+
+	var errs []error
+
+	for port, portMode := range mtls.PortModes {
+		portNumber, errPort := strconv.ParseUint(port, 10, 32)
+		if errPort != nil {
+			errs = append(errs, fmt.Errorf("invalid port %q: %w", port, errPort))
+
+			continue
+		}
+
+		mode, errMode := parseMTLSMode(portMode)
+		if errMode != nil {
+			errs = append(errs, fmt.Errorf("invalid mode of port %q: %w", port, errMode))
+
+			continue
+		}
+
+		if peerAuthn.Spec.PortLevelMtls == nil {
+			peerAuthn.Spec.PortLevelMtls = map[uint32]*v1beta1.PeerAuthentication_MutualTLS{}
+		}
+
+		peerAuthn.Spec.PortLevelMtls[uint32(portNumber)] = &v1beta1.PeerAuthentication_MutualTLS{Mode: mode}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	metadata.ApplyMetaOptions(peerAuthn, labels.StandardLabelsFrom(target)...)
+
+	return peerAuthn, nil
+}
+
+func parseMTLSMode(mode string) (v1beta1.PeerAuthentication_MutualTLS_Mode, error) {
+	if mode == "" {
+		return v1beta1.PeerAuthentication_MutualTLS_UNSET, nil
+	}
+
+	value, found := v1beta1.PeerAuthentication_MutualTLS_Mode_value[strings.ToUpper(mode)]
+	if !found {
+		return v1beta1.PeerAuthentication_MutualTLS_UNSET, fmt.Errorf("unsupported mTLS mode %q", mode)
+	}
+
+	return v1beta1.PeerAuthentication_MutualTLS_Mode(value), nil
+}
@@ -2,6 +2,7 @@ package authzctrl_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -29,31 +30,47 @@ var _ = SynchronizedBeforeSuite(func(ctx context.Context) {
 		return
 	}
 
+	withPorts := protectedComponent("PortsComponent")
+	withPorts.Ports = []string{"8080"}
+
+	withPeerAuthentication := protectedComponent("MeshComponent")
+	withPeerAuthentication.PeerAuthentication = &platform.MTLSConfig{
+		Mode:      "STRICT",
+		PortModes: map[string]string{"9090": "PERMISSIVE"},
+	}
+
+	withIdentityHeaders := protectedComponent("HeadersComponent")
+	withIdentityHeaders.Ports = []string{"8080"}
+	withIdentityHeaders.IdentityHeaders = map[string]string{
+		"x-forwarded-user": "username",
+	}
+
+	withEnforcementScope := protectedComponent("ScopedComponent")
+	withEnforcementScope.Ports = []string{"8080"}
+	withEnforcementScope.IdentityHeaders = map[string]string{
+		"x-forwarded-user": "username",
+	}
+	withEnforcementScope.EnforcementScope = &platform.EnforcementScope{
+		ExportedHostsOnly: true,
+		ExemptNamespaces:  []string{"monitoring"},
+	}
+
+	withOIDC := providerConfig()
+	withOIDC.OIDC = authorization.OIDCConfig{
+		IssuerURL:     "https://sso.example.com/realms/opendatahub",
+		UsernameClaim: "preferred_username",
+		GroupsClaim:   "groups",
+	}
+
+	log := ctrl.Log.WithName("controllers").WithName("platform")
+
 	envTest, cancelFunc = test.StartWithControllers(
-		authzctrl.New(
-			nil,
-			ctrl.Log.WithName("controllers").WithName("platform"),
-			platform.ProtectedResource{
-				ResourceReference: platform.ResourceReference{
-					GroupVersionKind: schema.GroupVersionKind{
-						Version: "v1",
-						Group:   "opendatahub.io",
-						Kind:    "Component",
-					},
-					Resources: "components",
-				},
-				WorkloadSelector: map[string]string{
-					"component": "{{.metadata.name}}",
-				},
-				Ports:     []string{},
-				HostPaths: []string{"spec.host"},
-			},
-			authorization.ProviderConfig{
-				Label:        config.GetAuthorinoLabel(),
-				Audiences:    config.GetAuthAudience(),
-				ProviderName: config.GetAuthProvider(),
-			},
-		).SetupWithManager,
+		authzctrl.New(nil, log, protectedComponent("Component"), providerConfig()).SetupWithManager,
+		authzctrl.New(nil, log, withPorts, providerConfig()).SetupWithManager,
+		authzctrl.New(nil, log, withPeerAuthentication, providerConfig()).SetupWithManager,
+		authzctrl.New(nil, log, withIdentityHeaders, providerConfig()).SetupWithManager,
+		authzctrl.New(nil, log, withEnforcementScope, providerConfig()).SetupWithManager,
+		authzctrl.New(nil, log, protectedComponent("OIDCComponent"), withOIDC).SetupWithManager,
 	)
 
 }, func() {})
@@ -67,3 +84,31 @@ var _ = SynchronizedAfterSuite(func() {}, func() {
 	cancelFunc()
 	Expect(envTest.Stop()).To(Succeed())
 })
+
+// protectedComponent defines ProtectedResource with the default configuration for the given kind of test component.
+// Each kind is used to verify a single feature, see test/data/crds.
+func protectedComponent(kind string) platform.ProtectedResource {
+	return platform.ProtectedResource{
+		ResourceReference: platform.ResourceReference{
+			GroupVersionKind: schema.GroupVersionKind{
+				Version: "v1",
+				Group:   "opendatahub.io",
+				Kind:    kind,
+			},
+			Resources: strings.ToLower(kind) + "s",
+		},
+		WorkloadSelector: map[string]string{
+			"component": "{{.metadata.name}}",
+		},
+		Ports:     []string{},
+		HostPaths: []string{"spec.host"},
+	}
+}
+
+func providerConfig() authorization.ProviderConfig {
+	return authorization.ProviderConfig{
+		Label:        config.GetAuthorinoLabel(),
+		Audiences:    config.GetAuthAudience(),
+		ProviderName: config.GetAuthProvider(),
+	}
+}
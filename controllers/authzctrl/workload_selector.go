@@ -0,0 +1,74 @@
+package authzctrl
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/opendatahub-io/odh-platform/pkg/config"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type workloadSelectorKey struct{}
+
+// resolvedWorkloadSelector is the outcome of resolving the workload selector for the reconciled target.
+type resolvedWorkloadSelector struct {
+	selector map[string]string
+	err      error
+}
+
+// withWorkloadSelector resolves the workload selector for the target and stores the outcome in the context, so that
+// sub-reconcilers relying on it do not report the same problem over and over again within a single reconcile.
+func (r *Controller) withWorkloadSelector(ctx context.Context, target *unstructured.Unstructured) context.Context {
+	selector, err := r.resolveWorkloadSelector(target)
+
+	return context.WithValue(ctx, workloadSelectorKey{}, resolvedWorkloadSelector{selector: selector, err: err})
+}
+
+// workloadSelector returns the workload selector resolved for the reconciled target, resolving it when the context
+// does not hold one.
+func (r *Controller) workloadSelector(ctx context.Context, target *unstructured.Unstructured) (map[string]string, error) {
+	resolved, found := ctx.Value(workloadSelectorKey{}).(resolvedWorkloadSelector)
+	if !found {
+		return r.resolveWorkloadSelector(target)
+	}
+
+	return maps.Clone(resolved.selector), resolved.err
+}
+
+// resolveWorkloadSelector resolves WorkloadSelector and WorkloadMatchExpressions of the ProtectedResource against the target.
+// Istio policies select workloads by matching labels only, so requirements which cannot be expressed that way are
+// reported on the target instead of applying a policy selecting more workloads than intended.
+func (r *Controller) resolveWorkloadSelector(target *unstructured.Unstructured) (map[string]string, error) {
+	resolvedSelectors, errResolve := config.ResolveSelectors(r.protectedResource.WorkloadSelector, target)
+	if errResolve != nil {
+		return nil, fmt.Errorf("could not resolve WorkloadSelectors err: %w", errResolve)
+	}
+
+	requirements, errResolveReqs := config.ResolveSelectorRequirements(r.protectedResource.WorkloadMatchExpressions, target)
+	if errResolveReqs != nil {
+		return nil, fmt.Errorf("could not resolve WorkloadMatchExpressions err: %w", errResolveReqs)
+	}
+
+	matchLabels, errConvert := platform.RequirementsAsMatchLabels(requirements)
+	if errConvert != nil {
+		r.recorder.Event(target, corev1.EventTypeWarning, "UnsupportedWorkloadSelector", errConvert.Error())
+
+		return nil, fmt.Errorf("unsupported WorkloadMatchExpressions: %w", errConvert)
+	}
+
+	for key, value := range matchLabels {
+		if existing, found := resolvedSelectors[key]; found && existing != value {
+			errConflict := fmt.Errorf("WorkloadSelector expects label %q to be %q, while WorkloadMatchExpressions expect %q", key, existing, value)
+			r.recorder.Event(target, corev1.EventTypeWarning, "UnsupportedWorkloadSelector", errConflict.Error())
+
+			return nil, errConflict
+		}
+
+		resolvedSelectors[key] = value
+	}
+
+	return resolvedSelectors, nil
+}
@@ -0,0 +1,203 @@
+package configctrl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/opendatahub-io/odh-platform/api/v1alpha1"
+	platformctrl "github.com/opendatahub-io/odh-platform/controllers"
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"github.com/opendatahub-io/odh-platform/pkg/routing"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const name = "platformconfig"
+
+type (
+	AuthorizationRegistry = platformctrl.Registry[platform.ProtectedResource, authorization.ProviderConfig]
+	RoutingRegistry       = platformctrl.Registry[platform.RoutingTarget, routing.IngressConfig]
+)
+
+// New creates controller applying the PlatformConfig of the given name to capability controllers
+// managed by the registries.
+func New(cli client.Client, log logr.Logger, configName string, authzRegistry *AuthorizationRegistry, routingRegistry *RoutingRegistry) *Controller {
+	return &Controller{
+		Client:        cli,
+		log:           log.WithValues("controller", name, "platformconfig", configName),
+		configName:    configName,
+		authorization: authzRegistry,
+		routing:       routingRegistry,
+	}
+}
+
+// Controller reconciles capability controllers against the PlatformConfig and reports the outcome in its status.
+type Controller struct {
+	client.Client
+	log           logr.Logger
+	configName    string
+	authorization *AuthorizationRegistry
+	routing       *RoutingRegistry
+}
+
+// +kubebuilder:rbac:groups=platform.opendatahub.io,resources=platformconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=platform.opendatahub.io,resources=platformconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+
+// Reconcile validates the PlatformConfig and, when valid, ensures controllers for all its capabilities are running.
+// Invalid configuration is not applied, so that controllers keep running with the last valid one.
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	platformConfig := &v1alpha1.PlatformConfig{}
+	if err := r.Client.Get(ctx, req.NamespacedName, platformConfig); err != nil {
+		if k8serr.IsNotFound(err) {
+			r.log.Info("platform config not found, keeping current configuration")
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed getting platform config: %w", err)
+	}
+
+	spec := platformConfig.Spec
+	status := &platformConfig.Status
+	status.ObservedGeneration = platformConfig.Generation
+
+	var errSync error
+
+	if errs := spec.Validate(); len(errs) > 0 {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               v1alpha1.ConditionValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ValidationFailed",
+			Message:            errs.ToAggregate().Error(),
+			ObservedGeneration: platformConfig.Generation,
+		})
+
+		// Capabilities of the last valid configuration can still be waiting for their resource kinds.
+		errSync = errors.Join(r.authorization.SetupPending(ctx), r.routing.SetupPending(ctx))
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               v1alpha1.ConditionValid,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Validated",
+			ObservedGeneration: platformConfig.Generation,
+		})
+
+		errSync = errors.Join(
+			r.authorization.Sync(ctx, spec.ProtectedResources, providerConfig(spec.Authorization)),
+			r.routing.Sync(ctx, spec.RoutingTargets, ingressConfig(spec.Ingress)),
+		)
+
+		readyCondition := metav1.Condition{
+			Type:               v1alpha1.ConditionReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ControllersRunning",
+			ObservedGeneration: platformConfig.Generation,
+		}
+
+		pending := append(gvkStrings(r.authorization.Pending()), gvkStrings(r.routing.Pending())...)
+
+		switch {
+		case errSync != nil:
+			readyCondition.Status = metav1.ConditionFalse
+			readyCondition.Reason = "SyncFailed"
+			readyCondition.Message = errSync.Error()
+		case len(pending) > 0:
+			readyCondition.Status = metav1.ConditionFalse
+			readyCondition.Reason = "CapabilitiesPending"
+			readyCondition.Message = "waiting for resource kinds to be installed: " + strings.Join(pending, "; ")
+		}
+
+		meta.SetStatusCondition(&status.Conditions, readyCondition)
+	}
+
+	status.ActiveCapabilities = v1alpha1.CapabilityKinds{
+		Routing:       gvkStrings(r.routing.Active()),
+		Authorization: gvkStrings(r.authorization.Active()),
+	}
+	status.PendingCapabilities = v1alpha1.CapabilityKinds{
+		Routing:       gvkStrings(r.routing.Pending()),
+		Authorization: gvkStrings(r.authorization.Pending()),
+	}
+
+	if errUpdate := r.Client.Status().Update(ctx, platformConfig); errUpdate != nil {
+		return ctrl.Result{}, errors.Join(errSync, fmt.Errorf("failed updating platform config status: %w", errUpdate))
+	}
+
+	return ctrl.Result{}, errSync
+}
+
+func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		// Ensures client is set - fall back to the one defined for the passed manager
+		r.Client = mgr.GetClient()
+	}
+
+	configNameMatches := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetName() == r.configName
+	})
+
+	//nolint:wrapcheck //reason there is no point in wrapping it
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.PlatformConfig{}, builder.WithPredicates(configNameMatches, predicate.GenerationChangedPredicate{})).
+		// Installed CRDs can make pending capabilities available.
+		WatchesMetadata(&apiextv1.CustomResourceDefinition{}, platformctrl.EnqueueConstant(types.NamespacedName{Name: r.configName})).
+		Complete(r)
+}
+
+func gvkStrings(gvks []schema.GroupVersionKind) []string {
+	values := make([]string, 0, len(gvks))
+	for _, gvk := range gvks {
+		values = append(values, gvk.String())
+	}
+
+	return values
+}
+
+// ingressConfig converts the spec to the configuration used by routing controllers.
+func ingressConfig(s v1alpha1.IngressSpec) routing.IngressConfig {
+	return routing.IngressConfig{
+		IngressSelectorLabel: s.IngressSelectorLabel,
+		IngressSelectorValue: s.IngressSelectorValue,
+		IngressService:       s.IngressService,
+		GatewayNamespace:     s.GatewayNamespace,
+	}
+}
+
+// providerConfig converts the spec to the configuration used by authorization controllers.
+func providerConfig(s v1alpha1.AuthorizationSpec) authorization.ProviderConfig {
+	return authorization.ProviderConfig{
+		Type:              authorization.ProviderType(s.Type),
+		Label:             s.Label,
+		Audiences:         s.Audiences,
+		ProviderName:      s.ProviderName,
+		TemplateNamespace: s.TemplateNamespace,
+		OIDC: authorization.OIDCConfig{
+			IssuerURL:     s.OIDC.IssuerURL,
+			Audiences:     s.OIDC.Audiences,
+			UsernameClaim: s.OIDC.UsernameClaim,
+			GroupsClaim:   s.OIDC.GroupsClaim,
+		},
+		ServiceAccountIssuer: authorization.JWTIssuerConfig{
+			IssuerURL: s.ServiceAccountIssuer.IssuerURL,
+			JWKSURI:   s.ServiceAccountIssuer.JWKSURI,
+		},
+		Cache: authorization.CacheConfig{
+			Key: s.Cache.Key,
+			TTL: s.Cache.TTL,
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package configctrl_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/api/v1alpha1"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"github.com/opendatahub-io/odh-platform/test"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Platform configuration", test.EnvTest(), Ordered, func() {
+
+	componentRef := platform.ResourceReference{
+		GroupVersionKind: schema.GroupVersionKind{
+			Group:   "opendatahub.io",
+			Version: "v1",
+			Kind:    "Component",
+		},
+		Resources: "components",
+	}
+
+	var platformConfig *v1alpha1.PlatformConfig
+
+	BeforeAll(func(ctx context.Context) {
+		platformConfig = &v1alpha1.PlatformConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: platformConfigName},
+			Spec: v1alpha1.PlatformConfigSpec{
+				ProtectedResources: []platform.ProtectedResource{
+					{
+						ResourceReference: componentRef,
+						WorkloadSelector:  map[string]string{"component": "{{.metadata.name}}"},
+						Ports:             []string{"8080"},
+					},
+				},
+			},
+		}
+		Expect(envTest.Client.Create(ctx, platformConfig)).To(Succeed())
+
+		DeferCleanup(func(ctx context.Context) {
+			Expect(envTest.Client.Delete(ctx, platformConfig)).To(Succeed())
+		})
+	})
+
+	It("should activate controllers for capabilities and report them in status", func(ctx context.Context) {
+		Eventually(func(g Gomega, ctx context.Context) {
+			current := &v1alpha1.PlatformConfig{}
+			g.Expect(envTest.Client.Get(ctx, client.ObjectKeyFromObject(platformConfig), current)).To(Succeed())
+
+			g.Expect(meta.IsStatusConditionTrue(current.Status.Conditions, v1alpha1.ConditionValid)).To(BeTrue())
+			g.Expect(meta.IsStatusConditionTrue(current.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+			g.Expect(current.Status.ActiveCapabilities.Authorization).To(ConsistOf(componentRef.GroupVersionKind.String()))
+			g.Expect(current.Status.ActiveCapabilities.Routing).To(BeEmpty())
+			g.Expect(current.Spec.Ingress.GatewayNamespace).To(Equal("opendatahub-services"), "defaults should be applied")
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should report validation errors and keep controllers running", func(ctx context.Context) {
+		// given
+		current := &v1alpha1.PlatformConfig{}
+		Expect(envTest.Client.Get(ctx, client.ObjectKeyFromObject(platformConfig), current)).To(Succeed())
+
+		// when
+		current.Spec.ProtectedResources = append(current.Spec.ProtectedResources, current.Spec.ProtectedResources[0])
+		Expect(envTest.Client.Update(ctx, current)).To(Succeed())
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) {
+			updated := &v1alpha1.PlatformConfig{}
+			g.Expect(envTest.Client.Get(ctx, client.ObjectKeyFromObject(platformConfig), updated)).To(Succeed())
+
+			valid := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionValid)
+			g.Expect(valid).ToNot(BeNil())
+			g.Expect(valid.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(valid.Message).To(ContainSubstring("spec.protectedResources[1].ref.gvk: Duplicate value"))
+			g.Expect(updated.Status.ActiveCapabilities.Authorization).To(ConsistOf(componentRef.GroupVersionKind.String()))
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+
+	It("should start controller once the resource kind is installed", func(ctx context.Context) {
+		// given
+		gadgetGVK := schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Gadget"}
+
+		current := &v1alpha1.PlatformConfig{}
+		Expect(envTest.Client.Get(ctx, client.ObjectKeyFromObject(platformConfig), current)).To(Succeed())
+
+		current.Spec.ProtectedResources = current.Spec.ProtectedResources[:1]
+		current.Spec.RoutingTargets = []platform.RoutingTarget{
+			{
+				ResourceReference: platform.ResourceReference{GroupVersionKind: gadgetGVK, Resources: "gadgets"},
+				ServiceSelector:   map[string]string{"gadget": "{{.metadata.name}}"},
+			},
+		}
+		Expect(envTest.Client.Update(ctx, current)).To(Succeed())
+
+		Eventually(func(g Gomega, ctx context.Context) {
+			updated := &v1alpha1.PlatformConfig{}
+			g.Expect(envTest.Client.Get(ctx, client.ObjectKeyFromObject(platformConfig), updated)).To(Succeed())
+
+			ready := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionReady)
+			g.Expect(ready).ToNot(BeNil())
+			g.Expect(ready.Reason).To(Equal("CapabilitiesPending"))
+			g.Expect(updated.Status.PendingCapabilities.Routing).To(ConsistOf(gadgetGVK.String()))
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+
+		// when
+		gadgetCRD := &apiextv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "gadgets.opendatahub.io"},
+			Spec: apiextv1.CustomResourceDefinitionSpec{
+				Group: gadgetGVK.Group,
+				Names: apiextv1.CustomResourceDefinitionNames{
+					Kind:     gadgetGVK.Kind,
+					ListKind: gadgetGVK.Kind + "List",
+					Plural:   "gadgets",
+					Singular: "gadget",
+				},
+				Scope: apiextv1.NamespaceScoped,
+				Versions: []apiextv1.CustomResourceDefinitionVersion{
+					{
+						Name:    gadgetGVK.Version,
+						Served:  true,
+						Storage: true,
+						Schema: &apiextv1.CustomResourceValidation{
+							OpenAPIV3Schema: &apiextv1.JSONSchemaProps{
+								Type:                   "object",
+								XPreserveUnknownFields: ptr.To(true),
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(envTest.Client.Create(ctx, gadgetCRD)).To(Succeed())
+		DeferCleanup(func(ctx context.Context) {
+			Expect(envTest.Client.Delete(ctx, gadgetCRD)).To(Succeed())
+		})
+
+		// then
+		Eventually(func(g Gomega, ctx context.Context) {
+			updated := &v1alpha1.PlatformConfig{}
+			g.Expect(envTest.Client.Get(ctx, client.ObjectKeyFromObject(platformConfig), updated)).To(Succeed())
+
+			g.Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+			g.Expect(updated.Status.PendingCapabilities.Routing).To(BeEmpty())
+			g.Expect(updated.Status.ActiveCapabilities.Routing).To(ConsistOf(gadgetGVK.String()))
+		}).
+			WithContext(ctx).
+			WithTimeout(test.DefaultTimeout).
+			WithPolling(test.DefaultPolling).
+			Should(Succeed())
+	})
+})
@@ -0,0 +1,63 @@
+package configctrl_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	platformctrl "github.com/opendatahub-io/odh-platform/controllers"
+	"github.com/opendatahub-io/odh-platform/controllers/authzctrl"
+	"github.com/opendatahub-io/odh-platform/controllers/configctrl"
+	"github.com/opendatahub-io/odh-platform/controllers/routingctrl"
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"github.com/opendatahub-io/odh-platform/pkg/routing"
+	"github.com/opendatahub-io/odh-platform/test"
+	"github.com/opendatahub-io/odh-platform/test/k8senvtest"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const platformConfigName = "odh-platform"
+
+var (
+	envTest    *k8senvtest.Client
+	cancelFunc context.CancelFunc
+)
+
+func TestController(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Platform configuration")
+}
+
+var _ = SynchronizedBeforeSuite(func() {
+	if !test.IsEnvTest() {
+		return
+	}
+
+	log := ctrl.Log.WithName("controllers").WithName("platform")
+
+	envTest, cancelFunc = test.StartWithControllers(func(mgr ctrl.Manager) error {
+		authzRegistry := platformctrl.NewRegistry(mgr, log,
+			func(component platform.ProtectedResource, config authorization.ProviderConfig) platformctrl.CapabilityController[platform.ProtectedResource, authorization.ProviderConfig] {
+				return authzctrl.New(nil, log, component, config)
+			})
+
+		routingRegistry := platformctrl.NewRegistry(mgr, log,
+			func(component platform.RoutingTarget, config routing.IngressConfig) platformctrl.CapabilityController[platform.RoutingTarget, routing.IngressConfig] {
+				return routingctrl.New(nil, log, component, config)
+			})
+
+		return configctrl.New(nil, log, platformConfigName, authzRegistry, routingRegistry).SetupWithManager(mgr)
+	})
+}, func() {})
+
+var _ = SynchronizedAfterSuite(func() {}, func() {
+	if !test.IsEnvTest() {
+		return
+	}
+
+	By("Tearing down the test environment")
+	cancelFunc()
+	Expect(envTest.Stop()).To(Succeed())
+})
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// CRDWatcher invokes the handler whenever any CustomResourceDefinition changes, e.g. when it gets established,
+// so that controllers waiting for their resource kinds to be installed can be created.
+type CRDWatcher struct {
+	onChange func(ctx context.Context) error
+}
+
+func NewCRDWatcher(onChange func(ctx context.Context) error) *CRDWatcher {
+	return &CRDWatcher{onChange: onChange}
+}
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+
+// Reconcile invokes the handler. All changes are enqueued using the same request, so that bursts of changes,
+// such as installation of an operator with many CRDs, result in a few invocations only.
+func (w *CRDWatcher) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	return ctrl.Result{}, w.onChange(ctx)
+}
+
+func (w *CRDWatcher) SetupWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck //reason there is no point in wrapping it
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("crd-watcher").
+		WatchesMetadata(&apiextv1.CustomResourceDefinition{}, EnqueueConstant(types.NamespacedName{Name: "crd-changes"})).
+		Complete(w)
+}
+
+// EnqueueConstant maps events of any object to the given request.
+func EnqueueConstant(key types.NamespacedName) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: key}}
+	})
+}
@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/opendatahub-io/odh-platform/pkg/logging"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Capability is an entry of the capability configuration, such as platform.ProtectedResource or platform.RoutingTarget.
+type Capability interface {
+	GetResourceReference() platform.ResourceReference
+}
+
+// CapabilityController is a controller handling resources of a single GVK defined by the capability entry E,
+// using platform-wide configuration T.
+type CapabilityController[E Capability, T any] interface {
+	Activable[T]
+	Reconfigurable[E]
+	SetupWithManager(mgr ctrl.Manager) error
+}
+
+// Registry keeps track of controllers created for capability entries, so that the capability configuration
+// can be changed at runtime. As controllers cannot be removed from the running manager, controllers of removed
+// entries are deactivated instead, and activated again when the entry is brought back.
+type Registry[E Capability, T any] struct {
+	mgr           ctrl.Manager
+	log           logr.Logger
+	newController func(entry E, config T) CapabilityController[E, T]
+
+	mu          sync.Mutex
+	controllers map[schema.GroupVersionKind]*registeredController[E, T]
+}
+
+type registeredController[E Capability, T any] struct {
+	// controller is nil while the GVK is not served by the API server.
+	controller CapabilityController[E, T]
+	entry      E
+	config     T
+	active     bool
+}
+
+func NewRegistry[E Capability, T any](mgr ctrl.Manager, log logr.Logger, newController func(entry E, config T) CapabilityController[E, T]) *Registry[E, T] {
+	return &Registry[E, T]{
+		mgr:           mgr,
+		log:           log,
+		newController: newController,
+		controllers:   map[schema.GroupVersionKind]*registeredController[E, T]{},
+	}
+}
+
+// Sync ensures there is an active controller for each of the entries, using the given config:
+//   - controllers for new GVKs are created and registered with the manager,
+//   - controllers which entry or config changed are reconfigured and all their resources are requeued,
+//   - controllers for GVKs no longer present in the entries are deactivated.
+//
+// GVKs not served by the API server yet (e.g. their CRD is installed later by another operator) are kept
+// pending, and their controllers are created by SetupPending once they become available.
+func (r *Registry[E, T]) Sync(ctx context.Context, entries []E, config T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+
+	desired := make(map[schema.GroupVersionKind]bool, len(entries))
+
+	for _, entry := range entries {
+		gvk := entry.GetResourceReference().GroupVersionKind
+		desired[gvk] = true
+
+		registered, exists := r.controllers[gvk]
+		if !exists || registered.controller == nil {
+			r.controllers[gvk] = &registeredController[E, T]{entry: entry, config: config}
+			errs = append(errs, r.setup(gvk))
+
+			continue
+		}
+
+		if registered.active && reflect.DeepEqual(registered.entry, entry) && reflect.DeepEqual(registered.config, config) {
+			continue
+		}
+
+		registered.controller.Reconfigure(entry)
+		registered.controller.Activate(config)
+		registered.entry, registered.config, registered.active = entry, config, true
+
+		r.log.Info("controller reconfigured", logging.GVKKey, gvk.String())
+
+		if errRequeue := registered.controller.Requeue(ctx); errRequeue != nil {
+			errs = append(errs, fmt.Errorf("unable to requeue resources of %s: %w", gvk.String(), errRequeue))
+		}
+	}
+
+	for gvk, registered := range r.controllers {
+		if desired[gvk] {
+			continue
+		}
+
+		if registered.controller == nil {
+			delete(r.controllers, gvk)
+
+			continue
+		}
+
+		if !registered.active {
+			continue
+		}
+
+		registered.controller.Deactivate()
+		registered.active = false
+
+		r.log.Info("controller deactivated", logging.GVKKey, gvk.String())
+	}
+
+	return errors.Join(errs...)
+}
+
+// SetupPending creates controllers for pending GVKs which are now served by the API server.
+func (r *Registry[E, T]) SetupPending(_ context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+
+	for gvk, registered := range r.controllers {
+		if registered.controller == nil {
+			errs = append(errs, r.setup(gvk))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setup creates the controller for the registered entry, unless its GVK is not served by the API server.
+// It has to be called with the lock held.
+func (r *Registry[E, T]) setup(gvk schema.GroupVersionKind) error {
+	registered := r.controllers[gvk]
+
+	if _, errMapping := r.mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); errMapping != nil {
+		if meta.IsNoMatchError(errMapping) {
+			r.log.Info("resource kind is not available, controller creation is deferred until it is installed", logging.GVKKey, gvk.String())
+
+			return nil
+		}
+
+		return fmt.Errorf("unable to check availability of %s: %w", gvk.String(), errMapping)
+	}
+
+	controller := r.newController(registered.entry, registered.config)
+	if errSetup := controller.SetupWithManager(r.mgr); errSetup != nil {
+		return fmt.Errorf("unable to create controller for %s: %w", gvk.String(), errSetup)
+	}
+
+	registered.controller, registered.active = controller, true
+
+	r.log.Info("controller created", logging.GVKKey, gvk.String())
+
+	return nil
+}
+
+// Pending returns GVKs which controllers are waiting for the resource kind to be installed,
+// sorted by their string representation.
+func (r *Registry[E, T]) Pending() []schema.GroupVersionKind {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.sortedGVKs(func(registered *registeredController[E, T]) bool {
+		return registered.controller == nil
+	})
+}
+
+// Active returns GVKs handled by active controllers, sorted by their string representation.
+func (r *Registry[E, T]) Active() []schema.GroupVersionKind {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.sortedGVKs(func(registered *registeredController[E, T]) bool {
+		return registered.active
+	})
+}
+
+func (r *Registry[E, T]) sortedGVKs(include func(registered *registeredController[E, T]) bool) []schema.GroupVersionKind {
+	gvks := make([]schema.GroupVersionKind, 0, len(r.controllers))
+
+	for gvk, registered := range r.controllers {
+		if include(registered) {
+			gvks = append(gvks, gvk)
+		}
+	}
+
+	slices.SortFunc(gvks, func(a, b schema.GroupVersionKind) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return gvks
+}
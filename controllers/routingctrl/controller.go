@@ -5,20 +5,28 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	platformctrl "github.com/opendatahub-io/odh-platform/controllers"
+	"github.com/opendatahub-io/odh-platform/pkg/logging"
+	"github.com/opendatahub-io/odh-platform/pkg/metrics"
 	"github.com/opendatahub-io/odh-platform/pkg/platform"
 	"github.com/opendatahub-io/odh-platform/pkg/routing"
+	"github.com/opendatahub-io/odh-platform/pkg/tracing"
 	"github.com/opendatahub-io/odh-platform/pkg/unstruct"
 	openshiftroutev1 "github.com/openshift/api/route/v1"
 	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -30,24 +38,30 @@ func New(cli client.Client, log logr.Logger, target platform.RoutingTarget, conf
 	return &Controller{
 		active: true,
 		Client: cli,
-		log: log.WithValues(
+		log: log.WithName(controllerName(target.ResourceReference.Kind)).WithValues(
 			"controller", name,
 			"component", target.ResourceReference.Kind,
 		),
 		component:      target,
 		config:         config,
 		templateLoader: routing.NewStaticTemplateLoader(),
+		requeue:        make(chan event.GenericEvent),
+		drift:          metrics.NewDriftDetector(metrics.Routing),
 	}
 }
 
 // Controller holds the routing controller configuration.
 type Controller struct {
 	client.Client
+	// mu guards the configuration, which can be changed at runtime while resources are being reconciled.
+	mu             sync.RWMutex
 	active         bool
 	log            logr.Logger
 	component      platform.RoutingTarget
 	templateLoader routing.TemplateLoader
 	config         routing.IngressConfig
+	requeue        chan event.GenericEvent
+	drift          *metrics.DriftDetector
 }
 
 // +kubebuilder:rbac:groups="route.openshift.io",resources=routes,verbs=*
@@ -57,12 +71,12 @@ type Controller struct {
 // +kubebuilder:rbac:groups="",resources=services,verbs=*
 
 // Reconcile ensures that the component has all required resources needed to use routing capability of the platform.
-func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	if !r.active {
-		r.log.V(5).Info("controller is not active")
+func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, errReconcile error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-		return ctrl.Result{}, nil
-	}
+	ctx, log, span := platformctrl.StartReconcile(ctx, r.log, r.Name(), r.component.ResourceReference.GroupVersionKind, req)
+	defer func() { tracing.End(span, errReconcile) }()
 
 	reconcilers := []platformctrl.SubReconcileFunc{
 		r.removeUnusedRoutingResources,
@@ -74,7 +88,8 @@ func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	if err := r.Client.Get(ctx, req.NamespacedName, sourceRes); err != nil {
 		if k8serr.IsNotFound(err) {
-			r.log.Info("skipping reconcile. resource does not exist anymore", "resource", sourceRes.GroupVersionKind().String())
+			log.Info("skipping reconcile. resource does not exist anymore")
+			r.forget(req.NamespacedName)
 
 			return ctrl.Result{}, nil
 		}
@@ -82,10 +97,27 @@ func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, fmt.Errorf("failed getting resource: %w", err)
 	}
 
-	r.log.Info("triggered routing reconcile", "namespace", req.Namespace, "name", req.Name)
+	log = log.WithValues(logging.OwnerUIDKey, sourceRes.GetUID())
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// Inactive controller still handles deletion, so that resources are not blocked by the finalizer
+	// after the routing capability has been removed from the configuration.
+	if !r.active && !unstruct.IsMarkedForDeletion(sourceRes) {
+		log.V(5).Info("controller is not active")
+
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("triggered routing reconcile")
 
 	if unstruct.IsMarkedForDeletion(sourceRes) {
-		return ctrl.Result{}, r.handleResourceDeletion(ctx, sourceRes)
+		if errDelete := r.handleResourceDeletion(ctx, sourceRes); errDelete != nil {
+			return ctrl.Result{}, errDelete
+		}
+
+		r.forget(req.NamespacedName)
+
+		return ctrl.Result{}, nil
 	}
 
 	var errs []error
@@ -94,17 +126,41 @@ func (r *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, fmt.Errorf("failed adding finalizer: %w", errFinalizer)
 	}
 
+	hadAddresses := len(publishedHosts(sourceRes)) > 0
+
+	span.SetAttributes(tracing.ExportModesKey.StringSlice(exportModeNames(r.extractExportModes(sourceRes))))
+
 	for _, reconciler := range reconcilers {
-		errs = append(errs, reconciler(ctx, sourceRes))
+		if errStep := platformctrl.Traced(reconciler)(ctx, sourceRes); errStep != nil {
+			metrics.ReconcileFailed(metrics.Routing, sourceRes, reconciler.Name())
+			errs = append(errs, errStep)
+		}
 	}
 
-	errs = append(errs, unstruct.Patch(ctx, r.Client, sourceRes))
+	errPatch := unstruct.Patch(ctx, r.Client, sourceRes)
+	if errPatch == nil {
+		r.recordMetrics(sourceRes, hadAddresses)
+	}
+
+	errs = append(errs, errPatch)
 
 	return ctrl.Result{}, errors.Join(errs...)
 }
 
+// forget drops the state kept for the deleted resource.
+func (r *Controller) forget(key types.NamespacedName) {
+	metrics.ForgetComponent(metrics.Routing, r.component.ResourceReference.Kind, key.Namespace, key.Name)
+	r.drift.Forget(key)
+}
+
 func (r *Controller) Name() string {
-	return name + "-" + strings.ToLower(r.component.ResourceReference.Kind)
+	return controllerName(r.component.ResourceReference.Kind)
+}
+
+// controllerName is unique for each component kind. It also names the logger of the controller, so that
+// its verbosity can be configured separately.
+func controllerName(kind string) string {
+	return name + "-" + strings.ToLower(kind)
 }
 
 func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
@@ -127,16 +183,62 @@ func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&istionetworkingv1beta1.VirtualService{}).
 		Owns(&istionetworkingv1beta1.Gateway{}).
 		Owns(&openshiftroutev1.Route{}).
+		WatchesRawSource(&source.Channel{Source: r.requeue}, &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
 
 var _ platformctrl.Activable[routing.IngressConfig] = &Controller{}
 
 func (r *Controller) Activate(config routing.IngressConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.active = true
 	r.config = config
 }
 
 func (r *Controller) Deactivate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.active = false
+
+	metrics.ForgetKind(metrics.Routing, r.component.ResourceReference.Kind)
+}
+
+var _ platformctrl.Reconfigurable[platform.RoutingTarget] = &Controller{}
+
+// Reconfigure replaces the RoutingTarget definition. Its GVK is expected to stay the same.
+func (r *Controller) Reconfigure(target platform.RoutingTarget) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.component = target
+}
+
+// Requeue enqueues all watched resources. Events are delivered asynchronously, as the controller might not be
+// started yet (e.g. when waiting for leader election).
+func (r *Controller) Requeue(ctx context.Context) error {
+	r.mu.RLock()
+	gvk := r.component.ResourceReference.GroupVersionKind
+	r.mu.RUnlock()
+
+	targets := &metav1.PartialObjectMetadataList{}
+	targets.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := r.Client.List(ctx, targets); err != nil {
+		return fmt.Errorf("failed listing resources to requeue: %w", err)
+	}
+
+	go func() {
+		for i := range targets.Items {
+			select {
+			case r.requeue <- event.GenericEvent{Object: &targets.Items[i]}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
 }
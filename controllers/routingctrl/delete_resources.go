@@ -10,6 +10,7 @@ import (
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -31,12 +32,12 @@ func (r *Controller) removeUnusedRoutingResources(ctx context.Context, target *u
 func (r *Controller) handleResourceDeletion(ctx context.Context, sourceRes *unstructured.Unstructured) error {
 	exportModes := r.extractExportModes(sourceRes)
 	if len(exportModes) == 0 {
-		r.log.Info("No export modes found, skipping deletion logic", "sourceRes", sourceRes)
+		ctrl.LoggerFrom(ctx).Info("no export modes found, skipping deletion logic")
 
 		return nil
 	}
 
-	r.log.Info("Handling deletion of dependent resources", "sourceRes", sourceRes)
+	ctrl.LoggerFrom(ctx).Info("handling deletion of dependent resources", "exportModes", exportModes)
 
 	gvks := routingResourceGVKs(exportModes...)
 
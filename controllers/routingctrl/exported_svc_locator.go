@@ -5,16 +5,47 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/opendatahub-io/odh-platform/pkg/config"
+	"github.com/opendatahub-io/odh-platform/pkg/tracing"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getExportedServices(ctx context.Context, cli client.Client, labels map[string]string, target *unstructured.Unstructured) ([]corev1.Service, error) {
+// resolveServiceSelector resolves ServiceSelector and ServiceMatchExpressions of the RoutingTarget against the target.
+func (r *Controller) resolveServiceSelector(target *unstructured.Unstructured) (k8slabels.Selector, error) {
+	renderedSelectors, errLabels := config.ResolveSelectors(r.component.ServiceSelector, target)
+	if errLabels != nil {
+		return nil, fmt.Errorf("could not render labels for ServiceSelector %v: %w", r.component.ServiceSelector, errLabels)
+	}
+
+	requirements, errRequirements := config.ResolveSelectorRequirements(r.component.ServiceMatchExpressions, target)
+	if errRequirements != nil {
+		return nil, fmt.Errorf("could not render ServiceMatchExpressions: %w", errRequirements)
+	}
+
+	selector, errSelector := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      renderedSelectors,
+		MatchExpressions: requirements,
+	})
+	if errSelector != nil {
+		return nil, fmt.Errorf("invalid Service selector: %w", errSelector)
+	}
+
+	return selector, nil
+}
+
+func getExportedServices(ctx context.Context, cli client.Client, selector k8slabels.Selector,
+	target *unstructured.Unstructured) (_ []corev1.Service, errGet error) {
+	ctx, span := tracing.Start(ctx, "getExportedServices")
+	defer func() { tracing.End(span, errGet) }()
+
 	listOpts := []client.ListOption{
 		client.InNamespace(target.GetNamespace()),
-		client.MatchingLabels(labels),
+		client.MatchingLabelsSelector{Selector: selector},
 	}
 
 	var exportedSvcList *corev1.ServiceList
@@ -0,0 +1,54 @@
+package routingctrl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/odh-platform/pkg/metadata"
+	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
+	"github.com/opendatahub-io/odh-platform/pkg/metrics"
+	"github.com/opendatahub-io/odh-platform/pkg/routing"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// recordMetrics records export modes and published hosts of the reconciled target. When the target had no addresses
+// published before the reconciliation, the time it took to publish them is recorded as well.
+func (r *Controller) recordMetrics(target *unstructured.Unstructured, hadAddresses bool) {
+	metrics.SetExportModes(target, exportModeNames(r.extractExportModes(target)))
+
+	hosts := publishedHosts(target)
+	metrics.SetExportedHosts(target, len(hosts))
+
+	if !hadAddresses && len(hosts) > 0 {
+		metrics.AddressesPublished(target, time.Now())
+	}
+}
+
+// recordManagedResources records the number of applied resources of each kind the routing capability can create.
+func recordManagedResources(target *unstructured.Unstructured, applied map[schema.GroupKind]int) {
+	for _, gvk := range routingResourceGVKs(routing.AllRouteTypes()...) {
+		metrics.SetManagedResources(metrics.Routing, target, gvk.GroupKind(), applied[gvk.GroupKind()])
+	}
+}
+
+func exportModeNames(exportModes []routing.RouteType) []string {
+	names := make([]string, len(exportModes))
+	for i, exportMode := range exportModes {
+		names[i] = string(exportMode)
+	}
+
+	return names
+}
+
+func publishedHosts(target *unstructured.Unstructured) []string {
+	var hosts []string
+
+	for _, key := range metadata.Keys(annotations.RoutingAddressesExternal(""), annotations.RoutingAddressesPublic("")) {
+		if value := target.GetAnnotations()[key]; value != "" {
+			hosts = append(hosts, strings.Split(value, ";")...)
+		}
+	}
+
+	return hosts
+}
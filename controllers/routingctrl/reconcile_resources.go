@@ -7,7 +7,7 @@ import (
 	"strings"
 
 	"github.com/opendatahub-io/odh-platform/pkg/cluster"
-	"github.com/opendatahub-io/odh-platform/pkg/config"
+	"github.com/opendatahub-io/odh-platform/pkg/logging"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata/annotations"
 	"github.com/opendatahub-io/odh-platform/pkg/metadata/labels"
@@ -15,14 +15,25 @@ import (
 	"github.com/opendatahub-io/odh-platform/pkg/unstruct"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func (r *Controller) createRoutingResources(ctx context.Context, target *unstructured.Unstructured) error {
+	applied := map[schema.GroupKind]int{}
+	defer recordManagedResources(target, applied)
+
+	observeDrift := r.drift.Observe(target)
+	observe := func(desired, appliedRes *unstructured.Unstructured) {
+		observeDrift(desired, appliedRes)
+		applied[appliedRes.GroupVersionKind().GroupKind()]++
+	}
+
 	exportModes := r.extractExportModes(target)
 
 	if len(exportModes) == 0 {
-		r.log.Info("No export mode found for target")
+		ctrl.LoggerFrom(ctx).Info("no export mode found for target")
 		metadata.ApplyMetaOptions(target,
 			annotations.Remove(annotations.RoutingAddressesExternal("")),
 			annotations.Remove(annotations.RoutingAddressesPublic("")),
@@ -31,17 +42,17 @@ func (r *Controller) createRoutingResources(ctx context.Context, target *unstruc
 		return nil
 	}
 
-	r.log.Info("Reconciling resources for target", "target", target)
+	ctrl.LoggerFrom(ctx).Info("reconciling resources for target", "exportModes", exportModes)
 
-	renderedSelectors, errLables := config.ResolveSelectors(r.component.ServiceSelector, target)
-	if errLables != nil {
-		return fmt.Errorf("could not render labels for ServiceSelector %v: %w", r.component.ServiceSelector, errLables)
+	serviceSelector, errSelector := r.resolveServiceSelector(target)
+	if errSelector != nil {
+		return errSelector
 	}
 
-	exportedServices, errSvcGet := getExportedServices(ctx, r.Client, renderedSelectors, target)
+	exportedServices, errSvcGet := getExportedServices(ctx, r.Client, serviceSelector, target)
 	if errSvcGet != nil {
 		if errors.Is(errSvcGet, &ExportedServiceNotFoundError{}) {
-			r.log.Info("no exported services found for target", "target", target)
+			ctrl.LoggerFrom(ctx).Info("no exported services found for target")
 
 			return nil
 		}
@@ -57,7 +68,7 @@ func (r *Controller) createRoutingResources(ctx context.Context, target *unstruc
 	var errSvcExport []error
 
 	for i := range exportedServices {
-		if errExport := r.exportService(ctx, target, &exportedServices[i], domain); errExport != nil {
+		if errExport := r.exportService(ctx, target, &exportedServices[i], domain, observe); errExport != nil {
 			errSvcExport = append(errSvcExport, errExport)
 		}
 	}
@@ -65,7 +76,8 @@ func (r *Controller) createRoutingResources(ctx context.Context, target *unstruc
 	return errors.Join(errSvcExport...)
 }
 
-func (r *Controller) exportService(ctx context.Context, target *unstructured.Unstructured, exportedSvc *corev1.Service, domain string) error {
+func (r *Controller) exportService(ctx context.Context, target *unstructured.Unstructured, exportedSvc *corev1.Service, domain string,
+	observe func(desired, applied *unstructured.Unstructured)) error {
 	exportModes := r.extractExportModes(target)
 
 	externalHosts := []string{}
@@ -84,7 +96,7 @@ func (r *Controller) exportService(ctx context.Context, target *unstructured.Uns
 			}
 
 			ownershipLabels = append(ownershipLabels, labels.ExportType(exportMode))
-			if errApply := unstruct.Apply(ctx, r.Client, resources, ownershipLabels...); errApply != nil {
+			if errApply := unstruct.ApplyObserved(ctx, r.Client, resources, observe, ownershipLabels...); errApply != nil {
 				return fmt.Errorf("could not apply routing resources for type %s: %w", exportMode, errApply)
 			}
 
@@ -145,10 +157,7 @@ func (r *Controller) extractExportModes(target *unstructured.Unstructured) []rou
 			if valid {
 				validRouteTypes = append(validRouteTypes, routeType)
 			} else {
-				r.log.Info("Invalid route type found",
-					"invalidRouteType", routeType,
-					"resourceName", target.GetName(),
-					"resourceNamespace", target.GetNamespace())
+				r.log.Info("invalid route type found", append(logging.Component(target), "invalidRouteType", routeType)...)
 			}
 		}
 	}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/opendatahub-io/odh-platform/pkg/logging"
+	"github.com/opendatahub-io/odh-platform/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// StartReconcile starts the span of reconciliation of the requested resource. The returned context holds
+// the logger identifying the resource and including the trace ID, which steps can obtain using ctrl.LoggerFrom.
+//
+//nolint:ireturn //reason the span is the one of OpenTelemetry API
+func StartReconcile(ctx context.Context, log logr.Logger, controllerName string,
+	gvk schema.GroupVersionKind, req ctrl.Request) (context.Context, logr.Logger, trace.Span) {
+	ctx, span := tracing.Start(ctx, controllerName+"/Reconcile",
+		tracing.GVKKey.String(gvk.String()),
+		tracing.NamespaceKey.String(req.Namespace),
+		tracing.NameKey.String(req.Name),
+	)
+
+	log = log.WithValues(
+		logging.GVKKey, gvk.String(),
+		logging.NamespaceKey, req.Namespace,
+		logging.NameKey, req.Name,
+	).WithValues(tracing.LogValues(ctx)...)
+
+	return ctrl.LoggerInto(ctx, log), log, span
+}
+
+// Traced wraps the step, so that it runs in its own span named after it.
+func Traced(step SubReconcileFunc) SubReconcileFunc {
+	name := step.Name()
+
+	return func(ctx context.Context, target *unstructured.Unstructured) error {
+		ctx, span := tracing.Start(ctx, name)
+		err := step(ctx, target)
+		tracing.End(span, err)
+
+		return err
+	}
+}
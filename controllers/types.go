@@ -2,6 +2,9 @@ package controllers
 
 import (
 	"context"
+	"reflect"
+	"runtime"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -12,6 +15,20 @@ type Activable[T any] interface {
 	Deactivate()
 }
 
+// Reconfigurable is implemented by controllers which capability entry can be replaced at runtime.
+type Reconfigurable[E any] interface {
+	Reconfigure(entry E)
+	// Requeue enqueues all resources watched by the controller, so that the current configuration is applied to them.
+	Requeue(ctx context.Context) error
+}
+
 type SetupWithManagerFunc func(mgr ctrl.Manager) error
 
 type SubReconcileFunc func(ctx context.Context, target *unstructured.Unstructured) error
+
+// Name returns the name of the function or method implementing the step, e.g. "createRoutingResources".
+func (f SubReconcileFunc) Name() string {
+	fullName := strings.TrimSuffix(runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name(), "-fm")
+
+	return fullName[strings.LastIndex(fullName, ".")+1:]
+}
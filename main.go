@@ -1,21 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/opendatahub-io/odh-platform/controllers"
 	"github.com/opendatahub-io/odh-platform/controllers/authzctrl"
+	"github.com/opendatahub-io/odh-platform/controllers/configctrl"
 	"github.com/opendatahub-io/odh-platform/controllers/routingctrl"
 	"github.com/opendatahub-io/odh-platform/pkg/authorization"
 	"github.com/opendatahub-io/odh-platform/pkg/config"
+	"github.com/opendatahub-io/odh-platform/pkg/logging"
+	"github.com/opendatahub-io/odh-platform/pkg/metrics"
 	"github.com/opendatahub-io/odh-platform/pkg/platform"
 	"github.com/opendatahub-io/odh-platform/pkg/routing"
 	pschema "github.com/opendatahub-io/odh-platform/pkg/schema"
+	"github.com/opendatahub-io/odh-platform/pkg/tracing"
 	"github.com/opendatahub-io/odh-platform/version"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	_ "k8s.io/client-go/plugin/pkg/client/auth" // Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.) to ensure that exec-entrypoint and run can make use of them.
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -28,26 +42,70 @@ var (
 	metricsAddr          string
 	enableLeaderElection bool
 	probeAddr            string
+	configReloadInterval time.Duration
+	platformConfigName   string
+	maxMetricsNamespaces int
+	tracesEndpoint       string
+	traceSampleRatio     float64
 )
 
+// tracesFlushTimeout bounds the time spent exporting remaining spans on exit.
+const tracesFlushTimeout = 5 * time.Second
+
 func init() { //nolint:gochecknoinits //reason this way we ensure schemes are always registered before we start anything
 	pschema.RegisterSchemes(scheme)
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(validate(os.Args[2:]))
+	}
+
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&configReloadInterval, "config-reload-interval", 15*time.Second,
+		"How often capability configuration files are checked for changes. Zero disables reloading.")
+	flag.StringVar(&platformConfigName, "platform-config", "",
+		"Name of the cluster-scoped PlatformConfig resource holding the platform configuration. "+
+			"When empty, configuration is read from environment variables and capability configuration files.")
+
+	flag.IntVar(&maxMetricsNamespaces, "metrics-max-namespaces", metrics.DefaultMaxNamespaces,
+		"Number of distinct namespaces used as label values of platform metrics. Components in other namespaces are "+
+			"reported as \""+metrics.OtherNamespaces+"\". Zero disables the namespace breakdown.")
+
+	flag.StringVar(&tracesEndpoint, "otlp-traces-endpoint", config.GetTracesEndpoint(),
+		"URL of OTLP/HTTP endpoint receiving reconciliation traces, e.g. http://otel-collector:4318/v1/traces. "+
+			"When empty, tracing is disabled.")
+	flag.Float64Var(&traceSampleRatio, "trace-sample-ratio", 1.0,
+		"Fraction of reconciliations which are traced, between 0 and 1.")
 
+	logLevels := logging.Levels{}
+	flag.Var(logLevels, "log-levels",
+		"Comma-separated verbosity overrides of capabilities or controllers, e.g. \"routing=2,authorization-inferenceservice=5\". "+
+			"Other loggers use the level defined by --zap-log-level.")
+
+	// Development mode is used unless --zap-devel=false is passed, which switches to JSON-encoded production logs.
 	opts := zap.Options{
-		Development: true, // TODO: expose as flag to alternate the mode for production instance.
+		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	ctrl.SetLogger(logging.New(&opts, logLevels))
+	metrics.LimitNamespaces(maxMetricsNamespaces)
+
+	shutdownTracing, errTracing := tracing.Setup(ctrl.Log.WithName("tracing"), tracing.Options{
+		Endpoint:    tracesEndpoint,
+		ServiceName: config.GetServiceName(),
+		SampleRatio: traceSampleRatio,
+	})
+	if errTracing != nil {
+		setupLog.Error(errTracing, "unable to set up tracing")
+		os.Exit(1)
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
@@ -57,6 +115,16 @@ func main() {
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
 		},
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				// Only AuthConfig templates are of interest, there is no need to cache all ConfigMaps in the cluster.
+				// This restriction applies to the whole manager: reading any other ConfigMap through the cached
+				// client (mgr.GetClient()) returns NotFound. Such reads have to use mgr.GetAPIReader() instead.
+				&corev1.ConfigMap{}: {
+					Field: fields.OneTermEqualSelector("metadata.name", authorization.TemplateConfigMapName),
+				},
+			},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to create manager")
@@ -66,52 +134,34 @@ func main() {
 	ctrlLog := ctrl.Log.WithName("controllers").WithName("platform")
 	ctrlLog.Info("creating controller instances", "version", version.Version, "commit", version.Commit, "build-time", version.BuildTime)
 
-	var protectedResources []platform.ProtectedResource
+	authzLog := ctrlLog.WithName("authorization")
+	authzRegistry := controllers.NewRegistry(mgr, authzLog,
+		func(component platform.ProtectedResource, config authorization.ProviderConfig) controllers.CapabilityController[platform.ProtectedResource, authorization.ProviderConfig] {
+			return authzctrl.New(mgr.GetClient(), authzLog, component, config)
+		})
 
-	authzPath := filepath.Join(config.GetConfigFile(), "authorization")
-	if errLoadAuthz := config.Load(&protectedResources, authzPath); errLoadAuthz != nil {
-		setupLog.Error(errLoadAuthz, "unable to load config from "+authzPath)
-		os.Exit(1)
-	}
+	routingLog := ctrlLog.WithName("routing")
+	routingRegistry := controllers.NewRegistry(mgr, routingLog,
+		func(component platform.RoutingTarget, config routing.IngressConfig) controllers.CapabilityController[platform.RoutingTarget, routing.IngressConfig] {
+			return routingctrl.New(mgr.GetClient(), routingLog, component, config)
+		})
 
-	authorizationConfig := authorization.ProviderConfig{
-		Label:        config.GetAuthorinoLabel(),
-		Audiences:    config.GetAuthAudience(),
-		ProviderName: config.GetAuthProvider(),
-	}
+	ctx := ctrl.SetupSignalHandler()
 
-	for _, component := range protectedResources {
-		if err = authzctrl.New(mgr.GetClient(), ctrlLog, component, authorizationConfig).
-			SetupWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create controller", "controller", "authorization", "component", component.ResourceReference.Kind)
+	if platformConfigName != "" {
+		if errSetup := configctrl.New(mgr.GetClient(), ctrlLog, platformConfigName, authzRegistry, routingRegistry).
+			SetupWithManager(mgr); errSetup != nil {
+			setupLog.Error(errSetup, "unable to create controller", "controller", "platformconfig")
 			os.Exit(1)
 		}
-	}
-
-	var routingTargets []platform.RoutingTarget
-
-	routingPath := filepath.Join(config.GetConfigFile(), "routing")
-	if errLoadRouting := config.Load(&routingTargets, routingPath); errLoadRouting != nil {
-		setupLog.Error(errLoadRouting, "unable to load config from "+routingPath)
-		os.Exit(1)
-	}
-
-	routingConfig := routing.IngressConfig{
-		IngressSelectorLabel: config.GetIngressSelectorKey(),
-		IngressSelectorValue: config.GetIngressSelectorValue(),
-		IngressService:       config.GetGatewayService(),
-		GatewayNamespace:     config.GetGatewayNamespace(),
-	}
+	} else {
+		setupFromFiles(ctx, mgr, ctrlLog, authzRegistry, routingRegistry)
 
-	for _, component := range routingTargets {
-		if err = routingctrl.New(
-			mgr.GetClient(),
-			ctrlLog,
-			component,
-			routingConfig,
-		).
-			SetupWithManager(mgr); err != nil {
-			setupLog.Error(err, "unable to create controller", "controller", "routing", "component", component.ResourceReference.Kind)
+		setupPending := func(ctx context.Context) error {
+			return errors.Join(authzRegistry.SetupPending(ctx), routingRegistry.SetupPending(ctx))
+		}
+		if errSetup := controllers.NewCRDWatcher(setupPending).SetupWithManager(mgr); errSetup != nil {
+			setupLog.Error(errSetup, "unable to create controller", "controller", "crd-watcher")
 			os.Exit(1)
 		}
 	}
@@ -128,8 +178,132 @@ func main() {
 
 	setupLog.Info("Starting manager")
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
+	errStart := mgr.Start(ctx)
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), tracesFlushTimeout)
+	if errShutdown := shutdownTracing(flushCtx); errShutdown != nil {
+		setupLog.Error(errShutdown, "unable to flush traces")
+	}
+
+	cancel()
+
+	if errStart != nil {
+		setupLog.Error(errStart, "problem running manager")
+		os.Exit(1)
+	}
+}
+
+// setupFromFiles creates controllers for capabilities defined in configuration files, using configuration
+// defined in environment variables, and watches the files for changes.
+func setupFromFiles(ctx context.Context, mgr ctrl.Manager, log logr.Logger,
+	authzRegistry *configctrl.AuthorizationRegistry, routingRegistry *configctrl.RoutingRegistry) {
+	authzConfig, errConfig := loadAuthorizationConfig()
+	if errConfig != nil {
+		setupLog.Error(errConfig, "invalid authorization configuration")
+		os.Exit(1)
+	}
+
+	authzPath := filepath.Join(config.GetConfigFile(), "authorization")
+	routingPath := filepath.Join(config.GetConfigFile(), "routing")
+
+	reload := func(ctx context.Context) error {
+		protectedResources, routingTargets, errLoad := loadCapabilities(authzPath, routingPath)
+		if errLoad != nil {
+			return errLoad
+		}
+
+		// Both files are parsed before any controller is touched, so that invalid configuration is not partially applied.
+		return errors.Join(
+			authzRegistry.Sync(ctx, protectedResources, authzConfig),
+			routingRegistry.Sync(ctx, routingTargets, loadIngressConfig()),
+		)
+	}
+
+	if errSync := reload(ctx); errSync != nil {
+		setupLog.Error(errSync, "unable to create controllers")
 		os.Exit(1)
 	}
+
+	if configReloadInterval > 0 {
+		watcher := config.NewWatcher(log.WithName("config-watcher"), configReloadInterval, reload, authzPath, routingPath)
+		if errWatch := mgr.Add(watcher); errWatch != nil {
+			setupLog.Error(errWatch, "unable to set up configuration watcher")
+			os.Exit(1)
+		}
+	}
+}
+
+// loadCapabilities loads and validates both capability configuration files.
+func loadCapabilities(authzPath, routingPath string) ([]platform.ProtectedResource, []platform.RoutingTarget, error) {
+	protectedResources, errLoadAuthz := platform.LoadProtectedResources(authzPath)
+	routingTargets, errLoadRouting := platform.LoadRoutingTargets(routingPath)
+
+	return protectedResources, routingTargets, errors.Join(errLoadAuthz, errLoadRouting)
+}
+
+// validate checks capability configuration files and configuration defined in environment variables
+// without starting the manager. It returns the exit code.
+func validate(args []string) int {
+	validateFlags := flag.NewFlagSet("validate", flag.ExitOnError)
+	configDir := validateFlags.String("config-dir", config.GetConfigFile(), "Directory holding authorization and routing configuration files.")
+	_ = validateFlags.Parse(args)
+
+	_, errConfig := loadAuthorizationConfig()
+	_, _, errLoad := loadCapabilities(filepath.Join(*configDir, "authorization"), filepath.Join(*configDir, "routing"))
+
+	if err := errors.Join(errConfig, errLoad); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+
+		return 1
+	}
+
+	fmt.Fprintln(os.Stdout, "configuration is valid")
+
+	return 0
+}
+
+func validateProviderType(providerConfig authorization.ProviderConfig) error {
+	if providerType := providerConfig.GetType(); providerType != authorization.AuthorinoProvider && providerType != authorization.IstioProvider {
+		return fmt.Errorf("unsupported authorization provider type %q defined in %s", providerType, config.AuthProviderType)
+	}
+
+	return nil
+}
+
+// loadAuthorizationConfig loads authorization provider configuration defined in environment variables.
+func loadAuthorizationConfig() (authorization.ProviderConfig, error) {
+	cacheTTL, errTTL := config.GetAuthCacheTTL()
+
+	providerConfig := authorization.ProviderConfig{
+		Type:              authorization.ProviderType(config.GetAuthProviderType()),
+		Label:             config.GetAuthorinoLabel(),
+		Audiences:         config.GetAuthAudience(),
+		ProviderName:      config.GetAuthProvider(),
+		TemplateNamespace: config.GetAuthTemplateNamespace(),
+		OIDC: authorization.OIDCConfig{
+			IssuerURL:     config.GetAuthOIDCIssuerURL(),
+			Audiences:     config.GetAuthOIDCAudience(),
+			UsernameClaim: config.GetAuthOIDCUsernameClaim(),
+			GroupsClaim:   config.GetAuthOIDCGroupsClaim(),
+		},
+		ServiceAccountIssuer: authorization.JWTIssuerConfig{
+			IssuerURL: config.GetAuthServiceAccountIssuerURL(),
+			JWKSURI:   config.GetAuthServiceAccountJWKSURI(),
+		},
+		Cache: authorization.CacheConfig{
+			Key: config.GetAuthCacheKey(),
+			TTL: cacheTTL,
+		},
+	}
+
+	return providerConfig, errors.Join(validateProviderType(providerConfig), errTTL)
+}
+
+func loadIngressConfig() routing.IngressConfig {
+	return routing.IngressConfig{
+		IngressSelectorLabel: config.GetIngressSelectorKey(),
+		IngressSelectorValue: config.GetIngressSelectorValue(),
+		IngressService:       config.GetGatewayService(),
+		GatewayNamespace:     config.GetGatewayNamespace(),
+	}
 }
@@ -0,0 +1,87 @@
+package authorization
+
+import (
+	"strings"
+
+	authorinov1beta2 "github.com/kuadrant/authorino/api/v1beta2"
+)
+
+// IdentityAllowList defines groups and users which are granted access to the protected resource.
+// Identity is allowed when it belongs to any of the groups or matches any of the usernames.
+type IdentityAllowList struct {
+	Groups []string
+	Users  []string
+}
+
+// ParseIdentityAllowList creates IdentityAllowList from comma-separated lists of groups and users.
+func ParseIdentityAllowList(groups, users string) IdentityAllowList {
+	return IdentityAllowList{
+		Groups: splitCommaSeparated(groups),
+		Users:  splitCommaSeparated(users),
+	}
+}
+
+func (l IdentityAllowList) IsEmpty() bool {
+	return len(l.Groups) == 0 && len(l.Users) == 0
+}
+
+// AllowListRule creates Authorino pattern-matching authorization rule allowing only identities from the allow-list.
+func AllowListRule(allowList IdentityAllowList) authorinov1beta2.AuthorizationSpec {
+	alternatives := make([]authorinov1beta2.UnstructuredPatternExpressionOrRef, 0, len(allowList.Groups)+len(allowList.Users))
+
+	for _, group := range allowList.Groups {
+		alternatives = append(alternatives, identityPattern("groups", "incl", group))
+	}
+
+	for _, user := range allowList.Users {
+		alternatives = append(alternatives, identityPattern("username", "eq", user))
+	}
+
+	return authorinov1beta2.AuthorizationSpec{
+		AuthorizationMethodSpec: authorinov1beta2.AuthorizationMethodSpec{
+			PatternMatching: &authorinov1beta2.PatternMatchingAuthorizationSpec{
+				Patterns: []authorinov1beta2.PatternExpressionOrRef{{Any: alternatives}},
+			},
+		},
+	}
+}
+
+// NotInAllowListConditions creates Authorino conditions which are only met for identities outside the allow-list.
+// Used as "when" conditions, they make the evaluator skipped for identities from the allow-list.
+func NotInAllowListConditions(allowList IdentityAllowList) []authorinov1beta2.PatternExpressionOrRef {
+	conditions := make([]authorinov1beta2.PatternExpressionOrRef, 0, len(allowList.Groups)+len(allowList.Users))
+
+	for _, group := range allowList.Groups {
+		conditions = append(conditions, identityPattern("groups", "excl", group).PatternExpressionOrRef)
+	}
+
+	for _, user := range allowList.Users {
+		conditions = append(conditions, identityPattern("username", "neq", user).PatternExpressionOrRef)
+	}
+
+	return conditions
+}
+
+func identityPattern(attribute, operator, value string) authorinov1beta2.UnstructuredPatternExpressionOrRef {
+	return authorinov1beta2.UnstructuredPatternExpressionOrRef{
+		PatternExpressionOrRef: authorinov1beta2.PatternExpressionOrRef{
+			PatternExpression: authorinov1beta2.PatternExpression{
+				Selector: "auth.identity." + attribute,
+				Operator: authorinov1beta2.PatternExpressionOperator(operator),
+				Value:    value,
+			},
+		},
+	}
+}
+
+func splitCommaSeparated(value string) []string {
+	var values []string
+
+	for _, v := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(v); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,54 @@
+package authorization_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
+	"github.com/opendatahub-io/odh-platform/test"
+)
+
+var _ = Describe("Identity allow-list", test.Unit(), func() {
+
+	It("should parse comma-separated groups and users ignoring empty entries", func() {
+		// when
+		allowList := authorization.ParseIdentityAllowList("data-science, admins,", "")
+
+		// then
+		Expect(allowList.Groups).To(HaveExactElements("data-science", "admins"))
+		Expect(allowList.Users).To(BeEmpty())
+		Expect(allowList.IsEmpty()).To(BeFalse())
+	})
+
+	It("should allow any of the groups or users", func() {
+		// given
+		allowList := authorization.IdentityAllowList{Groups: []string{"data-science"}, Users: []string{"alice"}}
+
+		// when
+		rule := authorization.AllowListRule(allowList)
+
+		// then
+		Expect(rule.PatternMatching).ToNot(BeNil())
+		Expect(rule.PatternMatching.Patterns).To(HaveLen(1))
+		Expect(rule.PatternMatching.Patterns[0].Any).To(HaveLen(2))
+		Expect(rule.PatternMatching.Patterns[0].Any[0].Selector).To(Equal("auth.identity.groups"))
+		Expect(rule.PatternMatching.Patterns[0].Any[0].Operator).To(BeEquivalentTo("incl"))
+		Expect(rule.PatternMatching.Patterns[0].Any[1].Selector).To(Equal("auth.identity.username"))
+		Expect(rule.PatternMatching.Patterns[0].Any[1].Operator).To(BeEquivalentTo("eq"))
+		Expect(rule.PatternMatching.Patterns[0].Any[1].Value).To(Equal("alice"))
+	})
+
+	It("should create conditions met only by identities outside of the allow-list", func() {
+		// given
+		allowList := authorization.IdentityAllowList{Groups: []string{"data-science"}, Users: []string{"alice"}}
+
+		// when
+		conditions := authorization.NotInAllowListConditions(allowList)
+
+		// then
+		Expect(conditions).To(HaveLen(2))
+		Expect(conditions[0].Selector).To(Equal("auth.identity.groups"))
+		Expect(conditions[0].Operator).To(BeEquivalentTo("excl"))
+		Expect(conditions[1].Selector).To(Equal("auth.identity.username"))
+		Expect(conditions[1].Operator).To(BeEquivalentTo("neq"))
+	})
+})
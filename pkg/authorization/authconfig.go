@@ -23,7 +23,10 @@ import (
 	"text/template"
 
 	authorinov1beta2 "github.com/kuadrant/authorino/api/v1beta2"
+	"github.com/opendatahub-io/odh-platform/pkg/config"
 	"github.com/opendatahub-io/odh-platform/pkg/schema"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +38,12 @@ var authConfigTemplateAnonymous []byte
 //go:embed template/authconfig_userdefined.yaml
 var authConfigTemplateUserDefined []byte
 
+//go:embed template/authconfig_oidc.yaml
+var authConfigTemplateOIDC []byte
+
+//go:embed template/authconfig_apikey.yaml
+var authConfigTemplateAPIKey []byte
+
 type staticTemplateLoader struct {
 }
 
@@ -44,15 +53,118 @@ func NewStaticTemplateLoader() *staticTemplateLoader {
 	return &staticTemplateLoader{}
 }
 
-func (s *staticTemplateLoader) Load(_ context.Context, authType AuthType, key types.NamespacedName, templateData map[string]any) (authorinov1beta2.AuthConfig, error) {
-	authConfig := authorinov1beta2.AuthConfig{}
+func (s *staticTemplateLoader) Load(_ context.Context, authType AuthType, _ types.NamespacedName, templateData map[string]any) (authorinov1beta2.AuthConfig, error) {
+	var templateContent []byte
 
-	templateContent := authConfigTemplateAnonymous
-	if authType == UserDefined {
+	switch authType {
+	case UserDefined:
 		templateContent = authConfigTemplateUserDefined
+	case OIDC:
+		templateContent = authConfigTemplateOIDC
+	case APIKey:
+		templateContent = authConfigTemplateAPIKey
+	default:
+		templateContent = authConfigTemplateAnonymous
+	}
+
+	return renderAuthConfig(templateContent, templateData)
+}
+
+type configMapTemplateLoader struct {
+	client           client.Client
+	clusterNamespace string
+	fallback         AuthConfigTemplateLoader
+}
+
+var _ AuthConfigTemplateLoader = (*configMapTemplateLoader)(nil)
+
+// NewConfigMapTemplateLoader creates a loader which looks up AuthConfig templates stored in the TemplateConfigMapName
+// ConfigMap. The ConfigMap in the namespace of the protected resource takes precedence over the one defined in
+// clusterNamespace, which serves as cluster-wide default. Empty clusterNamespace disables cluster-wide lookup.
+// When no template is defined for the given AuthType, fallback loader is used.
+func NewConfigMapTemplateLoader(cli client.Client, clusterNamespace string, fallback AuthConfigTemplateLoader) *configMapTemplateLoader {
+	return &configMapTemplateLoader{
+		client:           cli,
+		clusterNamespace: clusterNamespace,
+		fallback:         fallback,
+	}
+}
+
+func (c *configMapTemplateLoader) Load(ctx context.Context, authType AuthType, key types.NamespacedName, templateData map[string]any) (authorinov1beta2.AuthConfig, error) {
+	namespaces := []string{key.Namespace}
+	if c.clusterNamespace != "" && c.clusterNamespace != key.Namespace {
+		namespaces = append(namespaces, c.clusterNamespace)
+	}
+
+	for _, namespace := range namespaces {
+		templateContent, found, errLookup := c.lookupTemplate(ctx, namespace, authType)
+		if errLookup != nil {
+			return authorinov1beta2.AuthConfig{}, errLookup
+		}
+
+		if !found {
+			continue
+		}
+
+		authConfig, errRender := renderAuthConfig([]byte(templateContent), templateData)
+		if errRender != nil {
+			return authorinov1beta2.AuthConfig{}, &InvalidTemplateError{
+				ConfigMap: types.NamespacedName{Namespace: namespace, Name: TemplateConfigMapName},
+				AuthType:  authType,
+				Err:       errRender,
+			}
+		}
+
+		return authConfig, nil
 	}
 
-	resolvedTemplate, err := s.resolveTemplate(templateContent, templateData)
+	ac, err := c.fallback.Load(ctx, authType, key, templateData)
+	if err != nil {
+		return authorinov1beta2.AuthConfig{}, fmt.Errorf("could not load from fallback: %w", err)
+	}
+
+	return ac, nil
+}
+
+func (c *configMapTemplateLoader) lookupTemplate(ctx context.Context, namespace string, authType AuthType) (string, bool, error) {
+	templateCM := &corev1.ConfigMap{}
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: TemplateConfigMapName}, templateCM); err != nil {
+		if k8serr.IsNotFound(err) {
+			return "", false, nil
+		}
+
+		return "", false, fmt.Errorf("could not fetch %s/%s ConfigMap: %w", namespace, TemplateConfigMapName, err)
+	}
+
+	templateContent, found := templateCM.Data[string(authType)]
+
+	return templateContent, found, nil
+}
+
+// IsTemplateConfigMap checks if the given object is a ConfigMap holding AuthConfig templates.
+func IsTemplateConfigMap(obj client.Object) bool {
+	return obj.GetName() == TemplateConfigMapName
+}
+
+// InvalidTemplateError indicates that the AuthConfig template defined in the ConfigMap cannot be used.
+type InvalidTemplateError struct {
+	ConfigMap types.NamespacedName
+	AuthType  AuthType
+	Err       error
+}
+
+func (e *InvalidTemplateError) Error() string {
+	return fmt.Sprintf("invalid %s template in ConfigMap %s: %v", e.AuthType, e.ConfigMap.String(), e.Err)
+}
+
+func (e *InvalidTemplateError) Unwrap() error {
+	return e.Err
+}
+
+func renderAuthConfig(templateContent []byte, templateData map[string]any) (authorinov1beta2.AuthConfig, error) {
+	authConfig := authorinov1beta2.AuthConfig{}
+
+	resolvedTemplate, err := resolveTemplate(templateContent, templateData)
 	if err != nil {
 		return authConfig, fmt.Errorf("could not resolve auth template: %w", err)
 	}
@@ -65,8 +177,8 @@ func (s *staticTemplateLoader) Load(_ context.Context, authType AuthType, key ty
 	return authConfig, nil
 }
 
-func (s *staticTemplateLoader) resolveTemplate(tmpl []byte, data map[string]any) ([]byte, error) {
-	engine, err := template.New("authconfig").Parse(string(tmpl))
+func resolveTemplate(tmpl []byte, data map[string]any) ([]byte, error) {
+	engine, err := template.New("authconfig").Funcs(config.TemplateFuncs()).Parse(string(tmpl))
 	if err != nil {
 		return []byte{}, fmt.Errorf("could not create template engine: %w", err)
 	}
@@ -81,51 +193,38 @@ func (s *staticTemplateLoader) resolveTemplate(tmpl []byte, data map[string]any)
 	return buf.Bytes(), nil
 }
 
-type configMapTemplateLoader struct {
-	client   client.Client
-	fallback AuthConfigTemplateLoader
-}
-
-var _ AuthConfigTemplateLoader = (*configMapTemplateLoader)(nil)
-
-func NewConfigMapTemplateLoader(cli client.Client, fallback AuthConfigTemplateLoader) *configMapTemplateLoader {
-	return &configMapTemplateLoader{
-		client:   cli,
-		fallback: fallback,
-	}
-}
-
-// TODO: check "authconfig-template" CM in key.Namespace to see if there is a "spec" to use, construct a AuthConfig object
-// https://issues.redhat.com/browse/RHOAIENG-847
-func (c *configMapTemplateLoader) Load(ctx context.Context, authType AuthType, key types.NamespacedName, templateData map[string]any) (authorinov1beta2.AuthConfig, error) {
-	// else
-	ac, err := c.fallback.Load(ctx, authType, key, templateData)
-	if err != nil {
-		return authorinov1beta2.AuthConfig{}, fmt.Errorf("could not load from fallback: %w", err)
-	}
-
-	return ac, nil
-}
-
 type annotationAuthTypeDetector struct {
-	annotation string
+	annotation     string
+	typeAnnotation string
 }
 
 var _ AuthTypeDetector = (*annotationAuthTypeDetector)(nil)
 
-func NewAnnotationAuthTypeDetector(annotation string) *annotationAuthTypeDetector {
+// NewAnnotationAuthTypeDetector creates a detector enabling authentication when the given annotation is set to "true".
+// Authentication method is then determined by the value of typeAnnotation, defaulting to Kubernetes TokenReview (UserDefined).
+func NewAnnotationAuthTypeDetector(annotation, typeAnnotation string) *annotationAuthTypeDetector {
 	return &annotationAuthTypeDetector{
-		annotation: annotation,
+		annotation:     annotation,
+		typeAnnotation: typeAnnotation,
 	}
 }
 
 func (k *annotationAuthTypeDetector) Detect(_ context.Context, res *unstructured.Unstructured) (AuthType, error) {
 	// TODO: review controllers as package for consts
-	if value, exist := res.GetAnnotations()[k.annotation]; exist {
-		if strings.EqualFold(value, "true") {
-			return UserDefined, nil
-		}
+	resAnnotations := res.GetAnnotations()
+
+	if value, exist := resAnnotations[k.annotation]; !exist || !strings.EqualFold(value, "true") {
+		return Anonymous, nil
 	}
 
-	return Anonymous, nil
+	switch authType := strings.ToLower(resAnnotations[k.typeAnnotation]); authType {
+	case "", "kubernetes":
+		return UserDefined, nil
+	case string(OIDC):
+		return OIDC, nil
+	case string(APIKey):
+		return APIKey, nil
+	default:
+		return "", fmt.Errorf("unsupported value %q of %s annotation", authType, k.typeAnnotation)
+	}
 }
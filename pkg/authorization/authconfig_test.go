@@ -0,0 +1,127 @@
+package authorization_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
+	"github.com/opendatahub-io/odh-platform/test"
+	. "github.com/opendatahub-io/odh-platform/test/matchers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("AuthConfig templates", test.Unit(), func() {
+
+	Context("auth type detection", func() {
+
+		detector := authorization.NewAnnotationAuthTypeDetector("enable-auth", "auth-type")
+
+		DescribeTable("should detect auth type based on annotations",
+			func(ctx context.Context, annotations map[string]string, expected authorization.AuthType) {
+				// given
+				target := &unstructured.Unstructured{Object: map[string]any{}}
+				target.SetAnnotations(annotations)
+
+				// when
+				authType, err := detector.Detect(ctx, target)
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(authType).To(Equal(expected))
+			},
+			Entry("anonymous without annotations", nil, authorization.Anonymous),
+			Entry("anonymous when auth is not enabled", map[string]string{"enable-auth": "false", "auth-type": "oidc"}, authorization.Anonymous),
+			Entry("kubernetes by default when auth is enabled", map[string]string{"enable-auth": "true"}, authorization.UserDefined),
+			Entry("kubernetes when explicitly requested", map[string]string{"enable-auth": "true", "auth-type": "kubernetes"}, authorization.UserDefined),
+			Entry("oidc when requested", map[string]string{"enable-auth": "true", "auth-type": "OIDC"}, authorization.OIDC),
+			Entry("apikey when requested", map[string]string{"enable-auth": "true", "auth-type": "apikey"}, authorization.APIKey),
+		)
+
+		It("should fail on unsupported auth type", func(ctx context.Context) {
+			// given
+			target := &unstructured.Unstructured{Object: map[string]any{}}
+			target.SetAnnotations(map[string]string{"enable-auth": "true", "auth-type": "magic"})
+
+			// when
+			_, err := detector.Detect(ctx, target)
+
+			// then
+			Expect(err).To(MatchError(ContainSubstring("unsupported value \"magic\"")))
+		})
+	})
+
+	Context("static templates", func() {
+
+		It("should render OIDC template with claims mapping and audiences", func(ctx context.Context) {
+			// given
+			templateData := map[string]any{
+				"OIDC": authorization.OIDCConfig{
+					IssuerURL:     "https://sso.example.com/realms/odh",
+					Audiences:     []string{"odh"},
+					UsernameClaim: "email",
+					GroupsClaim:   "roles",
+				},
+			}
+
+			// when
+			authConfig, err := authorization.NewStaticTemplateLoader().Load(ctx, authorization.OIDC, types.NamespacedName{}, templateData)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(authConfig).To(HaveAuthenticationMethod("oidc-user"))
+
+			oidcUser := authConfig.Spec.Authentication["oidc-user"]
+			Expect(oidcUser.Jwt).ToNot(BeNil())
+			Expect(oidcUser.Jwt.IssuerUrl).To(Equal("https://sso.example.com/realms/odh"))
+			Expect(oidcUser.Overrides).To(HaveKeyWithValue("username", HaveField("Selector", "auth.identity.email")))
+			Expect(oidcUser.Overrides).To(HaveKeyWithValue("groups", HaveField("Selector", "auth.identity.roles")))
+			Expect(authConfig.Spec.Authorization).To(HaveKey("audience"))
+		})
+
+		It("should not verify audience for OIDC template when none defined", func(ctx context.Context) {
+			// given
+			templateData := map[string]any{
+				"OIDC": authorization.OIDCConfig{
+					IssuerURL:     "https://sso.example.com/realms/odh",
+					UsernameClaim: "preferred_username",
+					GroupsClaim:   "groups",
+				},
+			}
+
+			// when
+			authConfig, err := authorization.NewStaticTemplateLoader().Load(ctx, authorization.OIDC, types.NamespacedName{}, templateData)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(authConfig.Spec.Authorization).To(BeEmpty())
+		})
+
+		It("should render API key template selecting secrets using provided labels", func(ctx context.Context) {
+			// given
+			templateData := map[string]any{
+				"APIKeySelector": map[string]string{
+					"security.opendatahub.io/api-key":    "true",
+					"platform.opendatahub.io/owner-name": "my-model",
+				},
+			}
+
+			// when
+			authConfig, err := authorization.NewStaticTemplateLoader().Load(ctx, authorization.APIKey, types.NamespacedName{}, templateData)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(authConfig).To(HaveAuthenticationMethod("api-key"))
+
+			apiKey := authConfig.Spec.Authentication["api-key"]
+			Expect(apiKey.ApiKey).ToNot(BeNil())
+			Expect(apiKey.ApiKey.AllNamespaces).To(BeFalse())
+			Expect(apiKey.ApiKey.Selector.MatchLabels).To(Equal(map[string]string{
+				"security.opendatahub.io/api-key":    "true",
+				"platform.opendatahub.io/owner-name": "my-model",
+			}))
+			Expect(apiKey.Credentials.AuthorizationHeader.Prefix).To(Equal("APIKEY"))
+		})
+	})
+})
@@ -0,0 +1,105 @@
+package authorization
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	authorinov1beta2 "github.com/kuadrant/authorino/api/v1beta2"
+)
+
+// ClaimRequirement defines a claim of the authenticated identity which has to match one of the values.
+type ClaimRequirement struct {
+	Claim  string
+	Values []string
+	// Contains is set for claims holding a list of values (e.g. groups). It is sufficient when the list
+	// contains one of the values. Otherwise, the claim has to be equal to one of them.
+	Contains bool
+}
+
+// ParseClaimRequirements parses comma-separated list of claim requirements in the form of "claim=value" for claims
+// holding a single value, or "claim~=value" for claims holding a list of values. Alternative values of a single
+// requirement are separated by "|", e.g. "groups~=admins|data-science,email_verified=true".
+// All requirements have to be met.
+func ParseClaimRequirements(expression string) ([]ClaimRequirement, error) {
+	var requirements []ClaimRequirement
+
+	var errs []error
+
+	for _, requirement := range strings.Split(expression, ",") {
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" {
+			continue
+		}
+
+		claim, values, found := strings.Cut(requirement, "=")
+		contains := strings.HasSuffix(claim, "~")
+		claim = strings.TrimSpace(strings.TrimSuffix(claim, "~"))
+
+		if !found || claim == "" || strings.TrimSpace(values) == "" {
+			errs = append(errs, fmt.Errorf("expected claim requirement in claim=value or claim~=value format, got [%s]", requirement))
+
+			continue
+		}
+
+		claimRequirement := ClaimRequirement{Claim: claim, Contains: contains}
+		for _, value := range strings.Split(values, "|") {
+			claimRequirement.Values = append(claimRequirement.Values, strings.TrimSpace(value))
+		}
+
+		requirements = append(requirements, claimRequirement)
+	}
+
+	return requirements, errors.Join(errs...)
+}
+
+// ClaimRequirementsRule creates Authorino pattern-matching authorization rule enforcing all provided requirements
+// against the authenticated identity. Single-value claims are compared using "eq" operator, while "incl" is only
+// used for claims holding a list of values.
+func ClaimRequirementsRule(requirements []ClaimRequirement) authorinov1beta2.AuthorizationSpec {
+	patterns := make([]authorinov1beta2.PatternExpressionOrRef, len(requirements))
+
+	for i, requirement := range requirements {
+		operator := authorinov1beta2.PatternExpressionOperator("eq")
+		if requirement.Contains {
+			operator = "incl"
+		}
+
+		alternatives := make([]authorinov1beta2.UnstructuredPatternExpressionOrRef, len(requirement.Values))
+		for j, value := range requirement.Values {
+			alternatives[j] = authorinov1beta2.UnstructuredPatternExpressionOrRef{
+				PatternExpressionOrRef: authorinov1beta2.PatternExpressionOrRef{
+					PatternExpression: authorinov1beta2.PatternExpression{
+						Selector: "auth.identity." + requirement.Claim,
+						Operator: operator,
+						Value:    value,
+					},
+				},
+			}
+		}
+
+		patterns[i] = authorinov1beta2.PatternExpressionOrRef{Any: alternatives}
+	}
+
+	return authorinov1beta2.AuthorizationSpec{
+		AuthorizationMethodSpec: authorinov1beta2.AuthorizationMethodSpec{
+			PatternMatching: &authorinov1beta2.PatternMatchingAuthorizationSpec{
+				Patterns: patterns,
+			},
+		},
+	}
+}
+
+// ClaimConditionKey returns the key of Istio AuthorizationPolicy condition matching the claim of the request JWT.
+// As Istio has no notion of identity overrides, "username" and "groups" are mapped to the claims configured
+// for the OIDC provider. Nested claims expressed using dot notation (e.g. "realm_access.roles") are supported.
+func ClaimConditionKey(claim string, oidc OIDCConfig) string {
+	switch {
+	case claim == "username" && oidc.UsernameClaim != "":
+		claim = oidc.UsernameClaim
+	case claim == "groups" && oidc.GroupsClaim != "":
+		claim = oidc.GroupsClaim
+	}
+
+	return "request.auth.claims[" + strings.Join(strings.Split(claim, "."), "][") + "]"
+}
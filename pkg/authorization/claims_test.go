@@ -0,0 +1,85 @@
+package authorization_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
+	"github.com/opendatahub-io/odh-platform/test"
+)
+
+var _ = Describe("Claim requirements", test.Unit(), func() {
+
+	It("should parse requirements with alternative values", func() {
+		// when
+		requirements, err := authorization.ParseClaimRequirements("groups~=admins|data-science, email_verified=true")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements).To(HaveExactElements(
+			authorization.ClaimRequirement{Claim: "groups", Values: []string{"admins", "data-science"}, Contains: true},
+			authorization.ClaimRequirement{Claim: "email_verified", Values: []string{"true"}},
+		))
+	})
+
+	It("should fail on requirements not in claim=value format", func() {
+		// when
+		_, err := authorization.ParseClaimRequirements("groups,=admins,~=admins")
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("got [groups]")))
+		Expect(err).To(MatchError(ContainSubstring("got [=admins]")))
+		Expect(err).To(MatchError(ContainSubstring("got [~=admins]")))
+	})
+
+	It("should require all claims while accepting any of alternative values", func() {
+		// given
+		requirements := []authorization.ClaimRequirement{
+			{Claim: "groups", Values: []string{"admins", "data-science"}, Contains: true},
+			{Claim: "email_verified", Values: []string{"true"}},
+		}
+
+		// when
+		rule := authorization.ClaimRequirementsRule(requirements)
+
+		// then
+		Expect(rule.PatternMatching).ToNot(BeNil())
+		Expect(rule.PatternMatching.Patterns).To(HaveLen(2))
+		Expect(rule.PatternMatching.Patterns[0].Any).To(HaveLen(2))
+		Expect(rule.PatternMatching.Patterns[0].Any[1].Selector).To(Equal("auth.identity.groups"))
+		Expect(rule.PatternMatching.Patterns[0].Any[1].Operator).To(BeEquivalentTo("incl"))
+		Expect(rule.PatternMatching.Patterns[0].Any[1].Value).To(Equal("data-science"))
+	})
+
+	It("should compare scalar claims for equality", func() {
+		// given
+		requirements, errParse := authorization.ParseClaimRequirements("email_verified=true")
+		Expect(errParse).ToNot(HaveOccurred())
+
+		// when
+		rule := authorization.ClaimRequirementsRule(requirements)
+
+		// then
+		Expect(rule.PatternMatching.Patterns).To(HaveLen(1))
+		Expect(rule.PatternMatching.Patterns[0].Any).To(HaveLen(1))
+		Expect(rule.PatternMatching.Patterns[0].Any[0].Selector).To(Equal("auth.identity.email_verified"))
+		Expect(rule.PatternMatching.Patterns[0].Any[0].Operator).To(BeEquivalentTo("eq"))
+		Expect(rule.PatternMatching.Patterns[0].Any[0].Value).To(Equal("true"))
+	})
+
+	DescribeTable("should map claims to Istio AuthorizationPolicy condition keys",
+		func(claim, expectedKey string) {
+			// given
+			oidc := authorization.OIDCConfig{UsernameClaim: "preferred_username", GroupsClaim: "roles"}
+
+			// when
+			key := authorization.ClaimConditionKey(claim, oidc)
+
+			// then
+			Expect(key).To(Equal(expectedKey))
+		},
+		Entry("username mapped to configured claim", "username", "request.auth.claims[preferred_username]"),
+		Entry("groups mapped to configured claim", "groups", "request.auth.claims[roles]"),
+		Entry("other claims used as they are", "email_verified", "request.auth.claims[email_verified]"),
+		Entry("nested claims", "realm_access.roles", "request.auth.claims[realm_access][roles]"),
+	)
+})
@@ -0,0 +1,26 @@
+package authorization
+
+import (
+	authorinov1beta2 "github.com/kuadrant/authorino/api/v1beta2"
+)
+
+// IdentityHeaders creates Authorino success response headers passing attributes of the authenticated identity
+// to the workload. Headers are always set, with an empty value when the identity does not have the attribute,
+// so that values sent by the caller never reach the workload. Attributes holding a list of values are JSON encoded.
+func IdentityHeaders(headers map[string]string) map[string]authorinov1beta2.HeaderSuccessResponseSpec {
+	responseHeaders := make(map[string]authorinov1beta2.HeaderSuccessResponseSpec, len(headers))
+
+	for header, attribute := range headers {
+		responseHeaders[header] = authorinov1beta2.HeaderSuccessResponseSpec{
+			SuccessResponseSpec: authorinov1beta2.SuccessResponseSpec{
+				AuthResponseMethodSpec: authorinov1beta2.AuthResponseMethodSpec{
+					Plain: &authorinov1beta2.PlainAuthResponseSpec{
+						Selector: "{auth.identity." + attribute + "}",
+					},
+				},
+			},
+		}
+	}
+
+	return responseHeaders
+}
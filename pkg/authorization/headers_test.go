@@ -0,0 +1,25 @@
+package authorization_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/authorization"
+	"github.com/opendatahub-io/odh-platform/test"
+)
+
+var _ = Describe("Identity headers", test.Unit(), func() {
+
+	It("should resolve headers from attributes of the authenticated identity", func() {
+		// when
+		headers := authorization.IdentityHeaders(map[string]string{
+			"x-forwarded-user":   "username",
+			"x-forwarded-groups": "groups",
+		})
+
+		// then
+		Expect(headers).To(HaveLen(2))
+		Expect(headers["x-forwarded-user"].Plain).ToNot(BeNil())
+		Expect(headers["x-forwarded-user"].Plain.Selector).To(Equal("{auth.identity.username}"))
+		Expect(headers["x-forwarded-groups"].Plain.Selector).To(Equal("{auth.identity.groups}"))
+	})
+})
@@ -10,20 +10,99 @@ import (
 
 // ProviderConfig holds the configuration for the authorization component as defined by the platform.
 type ProviderConfig struct {
+	// Type selects the mechanism enforcing authorization. Defaults to AuthorinoProvider.
+	Type ProviderType
 	// Label in a format of key=value. It's used to target created AuthConfig by Authorino instance.
 	Label string
 	// Audiences is a list of audiences used in the AuthConfig template when performing TokenReview.
 	Audiences []string
 	// ProviderName is the name of the registered external authorization provider in Service Mesh.
 	ProviderName string
+	// TemplateNamespace is the namespace holding cluster-wide AuthConfig templates ConfigMap.
+	// When empty, only templates defined in the namespace of the protected resource are considered.
+	TemplateNamespace string
+	// OIDC holds the configuration of OpenID Connect provider used by OIDC AuthType.
+	OIDC OIDCConfig
+	// ServiceAccountIssuer holds the issuer of Kubernetes service account tokens. It is only used by IstioProvider
+	// to verify tokens for UserDefined AuthType, as TokenReview is not available without Authorino.
+	ServiceAccountIssuer JWTIssuerConfig
+	// Cache defines default caching of TokenReview and SubjectAccessReview results performed by Authorino.
+	// It can be overridden for an individual resource instance using annotations.
+	Cache CacheConfig
 }
 
+// GetType returns the configured ProviderType, falling back to AuthorinoProvider when not set.
+func (p ProviderConfig) GetType() ProviderType {
+	if p.Type == "" {
+		return AuthorinoProvider
+	}
+
+	return p.Type
+}
+
+// DefaultCacheKey is Authorino selector resolving the token sent by the caller.
+const DefaultCacheKey = "context.request.http.headers.authorization"
+
+// CacheConfig defines caching of results of Authorino evaluators calling Kubernetes API.
+type CacheConfig struct {
+	// Key is Authorino selector resolving the cache key, e.g. "context.request.http.headers.authorization".
+	// It has to identify the caller, so that results are never shared between different identities.
+	Key string
+	// TTL is the number of seconds the result is cached for. Caching is disabled when zero.
+	TTL int
+}
+
+// Enabled checks if the results should be cached.
+func (c CacheConfig) Enabled() bool {
+	return c.TTL > 0 && c.Key != ""
+}
+
+// ProviderType represents the mechanism used to enforce authorization.
+type ProviderType string
+
+const (
+	// AuthorinoProvider delegates authorization decisions to Authorino using AuthConfig and
+	// CUSTOM AuthorizationPolicy pointing to the registered external authorization provider.
+	AuthorinoProvider ProviderType = "authorino"
+	// IstioProvider relies solely on Istio. Tokens are verified using RequestAuthentication and
+	// requests are rejected using DENY AuthorizationPolicy.
+	IstioProvider ProviderType = "istio"
+)
+
+// JWTIssuerConfig holds the details needed to verify JWTs issued by the given issuer.
+type JWTIssuerConfig struct {
+	// IssuerURL is the value of the "iss" claim of accepted tokens.
+	IssuerURL string
+	// JWKSURI is the URL of JSON Web Key Set used to verify tokens. It is required for the service account issuer,
+	// otherwise it is discovered using OpenID Connect discovery of the issuer when empty.
+	JWKSURI string
+}
+
+// OIDCConfig holds the configuration of OpenID Connect provider issuing JWTs verified for OIDC AuthType.
+type OIDCConfig struct {
+	// IssuerURL is the URL of the OpenID Connect provider. It is used to discover JSON Web Key Set to verify tokens.
+	IssuerURL string
+	// Audiences is a list of accepted audiences of the token ("aud" claim). When empty, audience is not verified.
+	Audiences []string
+	// UsernameClaim is the name of the claim mapped to the "username" of the authenticated identity.
+	UsernameClaim string
+	// GroupsClaim is the name of the claim mapped to the "groups" of the authenticated identity.
+	GroupsClaim string
+}
+
+// TemplateConfigMapName is the name of the ConfigMap holding AuthConfig templates. Each key in its data
+// is an AuthType, and its value is an AuthConfig manifest resolved as a go template, using the same
+// data as the templates embedded in the platform.
+const TemplateConfigMapName = "authconfig-template"
+
 // AuthType represents the type of authentication to be used for a given resource.
 type AuthType string
 
 const (
 	UserDefined AuthType = "userdefined"
 	Anonymous   AuthType = "anonymous"
+	OIDC        AuthType = "oidc"
+	APIKey      AuthType = "apikey"
 )
 
 // AuthTypeDetector attempts to determine the AuthType for the given resource
@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/opendatahub-io/odh-platform/pkg/tracing"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func GetDomain(ctx context.Context, cli client.Client) (string, error) {
+func GetDomain(ctx context.Context, cli client.Client) (_ string, errDomain error) {
+	ctx, span := tracing.Start(ctx, "GetDomain")
+	defer func() { tracing.End(span, errDomain) }()
+
 	ingress := &unstructured.Unstructured{}
 	ingress.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "config.openshift.io",
@@ -1,14 +1,18 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"reflect"
+
+	"sigs.k8s.io/yaml"
 )
 
-// Load loads the configuration from the given path.
+// Load loads the configuration from the given path. Content can be either JSON or YAML.
+// Fields not defined by the instance type are rejected.
 func Load(instance any, configPath string) error {
 	rv := reflect.ValueOf(instance)
 	if rv.Kind() != reflect.Pointer || rv.IsNil() {
@@ -20,10 +24,48 @@ func Load(instance any, configPath string) error {
 		return fmt.Errorf("could not read config file [%s]: %w", configPath, err)
 	}
 
-	err = json.Unmarshal(content, instance)
+	err = yaml.UnmarshalStrict(content, instance)
 	if err != nil {
-		return fmt.Errorf("could not parse json content of [%s]: %w", configPath, err)
+		return fmt.Errorf("could not parse content of [%s]: %w", configPath, err)
 	}
 
 	return nil
 }
+
+// LoadEntries loads the list of configuration entries from the given path. Content can be either JSON or YAML.
+// Fields not defined by the entry type are rejected, and reported along with the index of the entry.
+func LoadEntries[T any](configPath string) ([]T, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file [%s]: %w", configPath, err)
+	}
+
+	jsonContent, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse content of [%s]: %w", configPath, err)
+	}
+
+	var rawEntries []json.RawMessage
+	if errList := json.Unmarshal(jsonContent, &rawEntries); errList != nil {
+		return nil, fmt.Errorf("could not parse content of [%s], expected list of entries: %w", configPath, errList)
+	}
+
+	entries := make([]T, len(rawEntries))
+
+	var errs []error
+
+	for i, rawEntry := range rawEntries {
+		decoder := json.NewDecoder(bytes.NewReader(rawEntry))
+		decoder.DisallowUnknownFields()
+
+		if errDecode := decoder.Decode(&entries[i]); errDecode != nil {
+			errs = append(errs, fmt.Errorf("[%d]: %w", i, errDecode))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("could not parse entries of [%s]: %w", configPath, errors.Join(errs...))
+	}
+
+	return entries, nil
+}
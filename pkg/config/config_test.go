@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"os"
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -30,5 +31,44 @@ var _ = Describe("Loading capabilities", test.Unit(), func() {
 			)
 		})
 
+		It("should load entries defined in YAML", func() {
+			// given
+			configPath := filepath.Join(GinkgoT().TempDir(), "authorization")
+			Expect(os.WriteFile(configPath, []byte(`
+- ref:
+    gvk:
+      group: opendatahub.io
+      version: v1
+      kind: Component
+  ports: ["8080"]
+`), 0o600)).To(Succeed())
+
+			// when
+			protectedResources, err := config.LoadEntries[platform.ProtectedResource](configPath)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(protectedResources).To(HaveLen(1))
+			Expect(protectedResources[0].Kind).To(Equal("Component"))
+		})
+
+		It("should reject unknown fields pointing at the file and the entry", func() {
+			// given
+			configPath := filepath.Join(GinkgoT().TempDir(), "authorization")
+			Expect(os.WriteFile(configPath, []byte(`[
+				{"ref": {"gvk": {"kind": "Component", "version": "v1"}}, "ports": ["8080"]},
+				{"ref": {"gvk": {"kind": "Registry", "version": "v1"}}, "workloadSelctor": {"app": "registry"}}
+			]`), 0o600)).To(Succeed())
+
+			// when
+			_, err := config.LoadEntries[platform.ProtectedResource](configPath)
+
+			// then
+			Expect(err).To(MatchError(And(
+				ContainSubstring(configPath),
+				ContainSubstring(`[1]: json: unknown field "workloadSelctor"`),
+			)))
+		})
+
 	})
 })
@@ -1,19 +1,35 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
 const (
 	AuthAudience              = "AUTH_AUDIENCE"
 	AuthProvider              = "AUTH_PROVIDER"
+	AuthProviderType          = "AUTH_PROVIDER_TYPE"
+	AuthSAIssuerURL           = "AUTH_SERVICE_ACCOUNT_ISSUER_URL"
+	AuthSAJWKSURI             = "AUTH_SERVICE_ACCOUNT_JWKS_URI"
+	AuthTemplateNamespace     = "AUTH_TEMPLATE_NAMESPACE"
+	AuthCacheKey              = "AUTH_CACHE_KEY"
+	AuthCacheTTL              = "AUTH_CACHE_TTL"
+	AuthOIDCIssuerURL         = "AUTH_OIDC_ISSUER_URL"
+	AuthOIDCAudience          = "AUTH_OIDC_AUDIENCE"
+	AuthOIDCUsernameClaim     = "AUTH_OIDC_USERNAME_CLAIM"
+	AuthOIDCGroupsClaim       = "AUTH_OIDC_GROUPS_CLAIM"
 	RouteGatewayNamespace     = "ROUTE_GATEWAY_NAMESPACE"
 	RouteGatewayService       = "ROUTE_GATEWAY_SERVICE"
 	RouteIngressSelectorKey   = "ROUTE_INGRESS_SELECTOR_KEY"
 	RouteIngressSelectorValue = "ROUTE_INGRESS_SELECTOR_VALUE"
 	AuthorinoLabelSelector    = "AUTHORINO_LABEL"
 	ConfigCapabilities        = "CONFIG_CAPABILITIES"
+	// Standard OpenTelemetry SDK variables, see https://opentelemetry.io/docs/specs/otel/protocol/exporter/
+	OTELExporterEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	OTELExporterTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	OTELServiceName            = "OTEL_SERVICE_NAME"
 )
 
 func GetAuthorinoLabel() string {
@@ -24,6 +40,18 @@ func GetAuthProvider() string {
 	return getEnvOr(AuthProvider, "opendatahub-auth-provider")
 }
 
+func GetAuthProviderType() string {
+	return getEnvOr(AuthProviderType, "authorino")
+}
+
+func GetAuthServiceAccountIssuerURL() string {
+	return getEnvOr(AuthSAIssuerURL, "https://kubernetes.default.svc")
+}
+
+func GetAuthServiceAccountJWKSURI() string {
+	return getEnvOr(AuthSAJWKSURI, "")
+}
+
 func GetAuthAudience() []string {
 	aud := getEnvOr(AuthAudience, "https://kubernetes.default.svc")
 	audiences := strings.Split(aud, ",")
@@ -35,6 +63,53 @@ func GetAuthAudience() []string {
 	return audiences
 }
 
+func GetAuthTemplateNamespace() string {
+	return getEnvOr(AuthTemplateNamespace, "")
+}
+
+func GetAuthCacheKey() string {
+	return getEnvOr(AuthCacheKey, "context.request.http.headers.authorization")
+}
+
+// GetAuthCacheTTL returns the number of seconds authorization results are cached for.
+func GetAuthCacheTTL() (int, error) {
+	value := getEnvOr(AuthCacheTTL, "0")
+
+	ttl, err := strconv.Atoi(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("expected non-negative number of seconds in %s, got [%s]", AuthCacheTTL, value)
+	}
+
+	return ttl, nil
+}
+
+func GetAuthOIDCIssuerURL() string {
+	return getEnvOr(AuthOIDCIssuerURL, "")
+}
+
+func GetAuthOIDCAudience() []string {
+	aud := getEnvOr(AuthOIDCAudience, "")
+	if aud == "" {
+		return nil
+	}
+
+	audiences := strings.Split(aud, ",")
+
+	for i := range audiences {
+		audiences[i] = strings.TrimSpace(audiences[i])
+	}
+
+	return audiences
+}
+
+func GetAuthOIDCUsernameClaim() string {
+	return getEnvOr(AuthOIDCUsernameClaim, "preferred_username")
+}
+
+func GetAuthOIDCGroupsClaim() string {
+	return getEnvOr(AuthOIDCGroupsClaim, "groups")
+}
+
 func GetConfigFile() string {
 	return getEnvOr(ConfigCapabilities, "/tmp/platform-capabilities")
 }
@@ -55,6 +130,24 @@ func GetIngressSelectorValue() string {
 	return getEnvOr(RouteIngressSelectorValue, "opendatahub-ingress-gateway")
 }
 
+// GetTracesEndpoint returns URL of OTLP/HTTP traces endpoint. When only the base endpoint is defined,
+// the default "/v1/traces" path is appended to it. Empty when neither is defined.
+func GetTracesEndpoint() string {
+	if endpoint := getEnvOr(OTELExporterTracesEndpoint, ""); endpoint != "" {
+		return endpoint
+	}
+
+	if endpoint := getEnvOr(OTELExporterEndpoint, ""); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+	}
+
+	return ""
+}
+
+func GetServiceName() string {
+	return getEnvOr(OTELServiceName, "odh-platform")
+}
+
 func getEnvOr(key, defaultValue string) string {
 	if env, defined := os.LookupEnv(key); defined {
 		return env
@@ -6,13 +6,15 @@ import (
 	"strings"
 	"text/template"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // ResolveSelectors uses golang template engine to resolve the expressions in the `selectorExpressions` map using
 // `source` as a data input. Both the keys and values are resolved against the source data.
 //
-// Note: expressions are resolved against the source using lowercase keys
+// Note: expressions are resolved against the source using lowercase keys. Functions defined in TemplateFuncs
+// can be used in the expressions.
 //
 // Example source:
 //
@@ -27,7 +29,7 @@ import (
 //	 }
 func ResolveSelectors(selectorExpressions map[string]string, source *unstructured.Unstructured) (map[string]string, error) {
 	resolved := make(map[string]string, len(selectorExpressions))
-	mainTemplate := template.New("unused_name").Option("missingkey=error")
+	mainTemplate := template.New("unused_name").Option("missingkey=error").Funcs(TemplateFuncs())
 
 	for key, val := range selectorExpressions {
 		var err error
@@ -54,6 +56,60 @@ func ResolveSelectors(selectorExpressions map[string]string, source *unstructure
 	return resolved, nil
 }
 
+// ResolveSelectorRequirements resolves keys and values of the requirements against the `source`,
+// the same way as ResolveSelectors does.
+func ResolveSelectorRequirements(requirements []metav1.LabelSelectorRequirement, source *unstructured.Unstructured) ([]metav1.LabelSelectorRequirement, error) {
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+
+	resolved := make([]metav1.LabelSelectorRequirement, 0, len(requirements))
+	mainTemplate := template.New("unused_name").Option("missingkey=error").Funcs(TemplateFuncs())
+
+	for _, requirement := range requirements {
+		resolvedKey, err := resolveIfTemplated(mainTemplate, requirement.Key, source)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve key %s: %w", requirement.Key, err)
+		}
+
+		var resolvedValues []string
+
+		for _, val := range requirement.Values {
+			resolvedVal, errVal := resolveIfTemplated(mainTemplate, val, source)
+			if errVal != nil {
+				return nil, fmt.Errorf("could not resolve value %s of key %s: %w", val, requirement.Key, errVal)
+			}
+
+			resolvedValues = append(resolvedValues, resolvedVal)
+		}
+
+		resolved = append(resolved, metav1.LabelSelectorRequirement{
+			Key:      resolvedKey,
+			Operator: requirement.Operator,
+			Values:   resolvedValues,
+		})
+	}
+
+	return resolved, nil
+}
+
+// ParseExpression checks the syntax of a selector expression without resolving it.
+func ParseExpression(expression string) error {
+	if _, err := template.New("unused_name").Option("missingkey=error").Funcs(TemplateFuncs()).Parse(expression); err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	return nil
+}
+
+func resolveIfTemplated(templ *template.Template, text string, source *unstructured.Unstructured) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	return resolve(templ, text, source)
+}
+
 func resolve(templ *template.Template, textTemplate string, source *unstructured.Unstructured) (string, error) {
 	tmpl, err := templ.Parse(textTemplate)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/opendatahub-io/odh-platform/pkg/config"
 	"github.com/opendatahub-io/odh-platform/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -48,4 +49,45 @@ var _ = Describe("Templated selectors", test.Unit(), func() {
 		})
 	})
 
+	Context("set-based requirements", func() {
+
+		It("should resolve keys and values of requirements", func() {
+			requirements := []metav1.LabelSelectorRequirement{
+				{Key: "serving.kserve.io/{{.kind | lower}}", Operator: metav1.LabelSelectorOpIn, Values: []string{"{{.metadata.name}}-predictor", "static"}},
+				{Key: "component", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"{{.metadata.name}}-transformer"}},
+				{Key: "{{.metadata.name}}-disabled", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}
+
+			target := unstructured.Unstructured{
+				Object: map[string]any{},
+			}
+			target.SetName("X")
+			target.SetKind("Y")
+
+			resolved, err := config.ResolveSelectorRequirements(requirements, &target)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(resolved).To(Equal([]metav1.LabelSelectorRequirement{
+				{Key: "serving.kserve.io/y", Operator: metav1.LabelSelectorOpIn, Values: []string{"X-predictor", "static"}},
+				{Key: "component", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"X-transformer"}},
+				{Key: "X-disabled", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}))
+		})
+
+		It("should fail on missing expression in values", func() {
+			requirements := []metav1.LabelSelectorRequirement{
+				{Key: "component", Operator: metav1.LabelSelectorOpIn, Values: []string{"{{.metadata.name}}"}},
+			}
+
+			target := unstructured.Unstructured{
+				Object: map[string]any{},
+			}
+			target.SetKind("Y")
+
+			_, err := config.ResolveSelectorRequirements(requirements, &target)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("could not resolve value {{.metadata.name}} of key component"))
+		})
+	})
+
 })
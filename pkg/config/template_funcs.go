@@ -0,0 +1,115 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	labelValueMaxLength = 63
+	shortHashLength     = 8
+)
+
+var labelUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`) //nolint:gochecknoglobals //reason: compiled once
+
+// TemplateFuncs returns functions available in selector expressions, as well as in routing and AuthConfig templates:
+//   - lower: converts the value to lower case, e.g. {{ .kind | lower }}
+//   - trunc: limits the value to the given number of characters, e.g. {{ .metadata.name | trunc 20 }}
+//   - default: falls back to the given value when the value is empty, e.g. {{ label . "app" | default "none" }}
+//   - replace: replaces all occurrences of a substring, e.g. {{ .metadata.name | replace "." "-" }}
+//   - shortHash: returns the first 8 characters of hex encoded SHA-256 of the value
+//   - labelSafe: replaces characters not allowed in label values and, when the value is too long,
+//     truncates it and appends its shortHash, so that distinct values remain distinct
+//   - annotation, label: returns the value of the given annotation or label of the resource, or empty string
+//     when not defined, e.g. {{ annotation . "opendatahub.io/display-name" }}
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"lower": func(value any) string {
+			return strings.ToLower(toString(value))
+		},
+		"trunc": func(length int, value any) string {
+			runes := []rune(toString(value))
+			if length < 0 || len(runes) <= length {
+				return string(runes)
+			}
+
+			return string(runes[:length])
+		},
+		"default": func(defaultValue, value any) any {
+			if isEmpty(value) {
+				return defaultValue
+			}
+
+			return value
+		},
+		"replace": func(old, replacement string, value any) string {
+			return strings.ReplaceAll(toString(value), old, replacement)
+		},
+		"shortHash": func(value any) string {
+			return shortHash(toString(value))
+		},
+		"labelSafe": func(value any) string {
+			return labelSafe(toString(value))
+		},
+		"annotation": func(source any, key string) string {
+			return metadataValue(source, "annotations", key)
+		},
+		"label": func(source any, key string) string {
+			return metadataValue(source, "labels", key)
+		},
+	}
+}
+
+func shortHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:])[:shortHashLength]
+}
+
+func labelSafe(value string) string {
+	sanitized := strings.Trim(labelUnsafeChars.ReplaceAllString(value, "-"), "._-")
+	if len(sanitized) <= labelValueMaxLength {
+		return sanitized
+	}
+
+	truncated := strings.TrimRight(sanitized[:labelValueMaxLength-shortHashLength-1], "._-")
+
+	return truncated + "-" + shortHash(value)
+}
+
+func metadataValue(source any, field, key string) string {
+	object, isMap := source.(map[string]any)
+	if !isMap {
+		return ""
+	}
+
+	value, _, _ := unstructured.NestedString(object, "metadata", field, key)
+
+	return value
+}
+
+func toString(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+func isEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	return reflect.ValueOf(value).IsZero()
+}
@@ -0,0 +1,61 @@
+package config_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/config"
+	"github.com/opendatahub-io/odh-platform/test"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var _ = Describe("Template functions", test.Unit(), func() {
+
+	var target *unstructured.Unstructured
+
+	BeforeEach(func() {
+		target = &unstructured.Unstructured{Object: map[string]any{}}
+		target.SetKind("InferenceService")
+		target.SetName("my.model-" + strings.Repeat("x", 70))
+		target.SetAnnotations(map[string]string{
+			"serving.kserve.io/deploymentMode": "Serverless",
+		})
+	})
+
+	DescribeTable("should resolve expressions using functions",
+		func(expression, expected string) {
+			resolved, err := config.ResolveSelectors(map[string]string{"key": expression}, target)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(HaveKeyWithValue("key", expected))
+		},
+		Entry("lower", `{{ .kind | lower }}`, "inferenceservice"),
+		Entry("trunc", `{{ .metadata.name | trunc 8 }}`, "my.model"),
+		Entry("replace", `{{ .metadata.name | trunc 8 | replace "." "-" }}`, "my-model"),
+		Entry("default for missing label", `{{ label . "app" | default "none" }}`, "none"),
+		Entry("default for defined value", `{{ .kind | default "none" }}`, "InferenceService"),
+		Entry("annotation with dotted key", `{{ annotation . "serving.kserve.io/deploymentMode" }}`, "Serverless"),
+		Entry("short hash", `{{ "my-model" | shortHash }}`, "98ad223a"),
+		Entry("label safe value", `{{ "my model/v1" | labelSafe }}`, "my-model-v1"),
+	)
+
+	It("should shorten long values into distinct valid label values", func() {
+		// given
+		otherTarget := target.DeepCopy()
+		otherTarget.SetName(target.GetName() + "y")
+
+		// when
+		resolved, err := config.ResolveSelectors(map[string]string{"name": `{{ .metadata.name | labelSafe }}`}, target)
+		Expect(err).ToNot(HaveOccurred())
+
+		otherResolved, err := config.ResolveSelectors(map[string]string{"name": `{{ .metadata.name | labelSafe }}`}, otherTarget)
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(validation.IsValidLabelValue(resolved["name"])).To(BeEmpty())
+		Expect(resolved["name"]).To(HaveLen(63))
+		Expect(resolved["name"]).ToNot(Equal(otherResolved["name"]))
+	})
+})
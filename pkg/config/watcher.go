@@ -0,0 +1,114 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"maps"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Watcher periodically checks configuration files and invokes the handler when any of them changes.
+// Polling is used instead of file system notifications, as mounted ConfigMaps are updated by swapping
+// symbolic links, which are not reliably tracked by notifications. A change is only considered handled
+// when the handler succeeds. Otherwise, the same content is retried with exponential backoff up to maxRetryDelay,
+// while any further change is handled on the next check.
+type Watcher struct {
+	log       logr.Logger
+	interval  time.Duration
+	paths     []string
+	onChange  func(ctx context.Context) error
+	checksums map[string][sha256.Size]byte
+	// failed holds checksums of the content the handler failed for, which is retried after retryDelay.
+	failed     map[string][sha256.Size]byte
+	retryDelay time.Duration
+	retryAt    time.Time
+}
+
+const maxRetryDelay = 10 * time.Minute
+
+// NewWatcher creates Watcher of the given files. Their current content is considered as already handled.
+func NewWatcher(log logr.Logger, interval time.Duration, onChange func(ctx context.Context) error, paths ...string) *Watcher {
+	watcher := &Watcher{
+		log:      log,
+		interval: interval,
+		paths:    paths,
+		onChange: onChange,
+	}
+
+	watcher.checksums, _ = watcher.detectChanges()
+
+	return watcher
+}
+
+// Start polls the files until the context is cancelled. It implements manager.Runnable.
+func (w *Watcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			checksums, changed := w.detectChanges()
+			if !changed {
+				continue
+			}
+
+			retrying := maps.Equal(checksums, w.failed)
+			if retrying && time.Now().Before(w.retryAt) {
+				continue
+			}
+
+			w.log.Info("configuration changed, reloading", "paths", w.paths)
+
+			if err := w.onChange(ctx); err != nil {
+				w.backOff(checksums, retrying)
+				w.log.Error(err, "failed reloading configuration", "retryIn", w.retryDelay)
+
+				continue
+			}
+
+			w.checksums, w.failed = checksums, nil
+		}
+	}
+}
+
+// backOff schedules the next attempt to handle the content which the handler failed for. The delay starts
+// at the polling interval and doubles with each failed attempt for the same content.
+func (w *Watcher) backOff(checksums map[string][sha256.Size]byte, retrying bool) {
+	if retrying {
+		w.retryDelay = min(2*w.retryDelay, max(maxRetryDelay, w.interval))
+	} else {
+		w.retryDelay = w.interval
+	}
+
+	w.failed = checksums
+	w.retryAt = time.Now().Add(w.retryDelay)
+}
+
+// NeedLeaderElection ensures all instances keep their configuration up to date, not only the leader.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// detectChanges computes current checksums of the files and reports whether any of them differs from the handled ones.
+func (w *Watcher) detectChanges() (map[string][sha256.Size]byte, bool) {
+	checksums := make(map[string][sha256.Size]byte, len(w.paths))
+	changed := false
+
+	for _, path := range w.paths {
+		// Missing or unreadable file is considered empty, its appearance is detected as a change.
+		content, _ := os.ReadFile(path)
+
+		checksums[path] = sha256.Sum256(content)
+		if w.checksums[path] != checksums[path] {
+			changed = true
+		}
+	}
+
+	return checksums, changed
+}
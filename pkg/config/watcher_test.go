@@ -0,0 +1,114 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/config"
+	"github.com/opendatahub-io/odh-platform/test"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("Watching configuration", test.Unit(), func() {
+
+	It("should invoke handler only when content of the file changes", func(ctx context.Context) {
+		// given
+		configPath := filepath.Join(GinkgoT().TempDir(), "authorization")
+		Expect(os.WriteFile(configPath, []byte(`[]`), 0o600)).To(Succeed())
+
+		var reloads atomic.Int32
+		watcher := config.NewWatcher(ctrl.Log, 10*time.Millisecond, func(context.Context) error {
+			reloads.Add(1)
+
+			return nil
+		}, configPath)
+
+		watcherCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go func() {
+			defer GinkgoRecover()
+			Expect(watcher.Start(watcherCtx)).To(Succeed())
+		}()
+
+		// when
+		Consistently(reloads.Load).WithTimeout(100 * time.Millisecond).Should(BeZero())
+		Expect(os.WriteFile(configPath, []byte(`[{"ports":["8080"]}]`), 0o600)).To(Succeed())
+
+		// then
+		Eventually(reloads.Load).WithTimeout(time.Second).Should(BeEquivalentTo(1))
+		Consistently(reloads.Load).WithTimeout(100 * time.Millisecond).Should(BeEquivalentTo(1))
+	})
+
+	It("should retry handling the change until the handler succeeds", func(ctx context.Context) {
+		// given
+		configPath := filepath.Join(GinkgoT().TempDir(), "authorization")
+		Expect(os.WriteFile(configPath, []byte(`[]`), 0o600)).To(Succeed())
+
+		var attempts atomic.Int32
+		watcher := config.NewWatcher(ctrl.Log, 10*time.Millisecond, func(context.Context) error {
+			if attempts.Add(1) < 3 {
+				return errors.New("registry not available")
+			}
+
+			return nil
+		}, configPath)
+
+		watcherCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go func() {
+			defer GinkgoRecover()
+			Expect(watcher.Start(watcherCtx)).To(Succeed())
+		}()
+
+		// when
+		Expect(os.WriteFile(configPath, []byte(`[{"ports":["8080"]}]`), 0o600)).To(Succeed())
+
+		// then
+		Eventually(attempts.Load).WithTimeout(time.Second).Should(BeEquivalentTo(3))
+		Consistently(attempts.Load).WithTimeout(100 * time.Millisecond).Should(BeEquivalentTo(3))
+	})
+
+	It("should back off retrying the same content and handle a new change right away", func(ctx context.Context) {
+		// given
+		configPath := filepath.Join(GinkgoT().TempDir(), "authorization")
+		Expect(os.WriteFile(configPath, []byte(`[]`), 0o600)).To(Succeed())
+
+		var attempts atomic.Int32
+		watcher := config.NewWatcher(ctrl.Log, 10*time.Millisecond, func(context.Context) error {
+			attempts.Add(1)
+
+			return errors.New("invalid configuration")
+		}, configPath)
+
+		watcherCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go func() {
+			defer GinkgoRecover()
+			Expect(watcher.Start(watcherCtx)).To(Succeed())
+		}()
+
+		// when
+		Expect(os.WriteFile(configPath, []byte(`[{"ports":["http"]}]`), 0o600)).To(Succeed())
+
+		// then
+		// retried after 10, 20, 40, 80 and 160 ms, instead of every 10 ms
+		Eventually(attempts.Load).WithTimeout(time.Second).Should(BeNumerically(">=", 2))
+		Consistently(attempts.Load).WithTimeout(300 * time.Millisecond).Should(BeNumerically("<", 10))
+
+		// when
+		failedAttempts := attempts.Load()
+		Expect(os.WriteFile(configPath, []byte(`[{"ports":["8080"]}]`), 0o600)).To(Succeed())
+
+		// then
+		Eventually(attempts.Load).WithTimeout(100 * time.Millisecond).Should(BeNumerically(">", failedAttempts))
+	})
+})
@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys used by controllers to identify objects in log lines. Objects are never logged as a whole,
+// as they can be large and hold sensitive data, e.g. in annotations.
+const (
+	GVKKey       = "gvk"
+	NamespaceKey = "namespace"
+	NameKey      = "name"
+	// OwnerUIDKey holds UID of the component, which owns resources created for it by the platform.
+	OwnerUIDKey = "ownerUID"
+	// ResourceKey holds reference to the resource managed for the component.
+	ResourceKey = "resource"
+)
+
+// Component returns key-value pairs identifying the component.
+func Component(component client.Object) []any {
+	return []any{
+		GVKKey, component.GetObjectKind().GroupVersionKind().String(),
+		NamespaceKey, component.GetNamespace(),
+		NameKey, component.GetName(),
+		OwnerUIDKey, component.GetUID(),
+	}
+}
+
+// Resource returns key-value pair identifying the resource managed for the component.
+func Resource(gvk schema.GroupVersionKind, resource client.Object) []any {
+	return []any{ResourceKey, ResourceRef{
+		GVK:       gvk.String(),
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
+	}}
+}
+
+// ResourceRef identifies the resource in structured logs.
+type ResourceRef struct {
+	GVK       string `json:"gvk"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
@@ -0,0 +1,157 @@
+package logging
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// Levels defines verbosity of loggers by their name, which is either a capability (e.g. "routing")
+// or a controller (e.g. "routing-inferenceservice"). It can be used as a flag value in the form of
+// comma-separated name=level pairs.
+type Levels map[string]int
+
+var _ flag.Value = Levels{}
+
+// Set parses comma-separated name=level pairs, e.g. "routing=2,authorization-inferenceservice=5".
+func (l Levels) Set(value string) error {
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, levelValue, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid log level %q: expected name=level", pair)
+		}
+
+		level, errLevel := strconv.ParseUint(strings.TrimSpace(levelValue), 10, 7)
+		if errLevel != nil {
+			return fmt.Errorf("invalid log level %q: level has to be a number between 0 and 127", pair)
+		}
+
+		l[strings.TrimSpace(name)] = int(level)
+	}
+
+	return nil
+}
+
+func (l Levels) String() string {
+	pairs := make([]string, 0, len(l))
+	for name, level := range l {
+		pairs = append(pairs, name+"="+strconv.Itoa(level))
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func (l Levels) max() int {
+	maxLevel := 0
+	for _, level := range l {
+		maxLevel = max(maxLevel, level)
+	}
+
+	return maxLevel
+}
+
+// New creates zap logger configured using the options, with verbosity of named loggers overridden by the levels.
+// Zap level is lowered to the highest verbosity in use, while loggers without an override keep the verbosity
+// defined by the options.
+func New(opts *ctrlzap.Options, levels Levels) logr.Logger {
+	defaultLevel := verbosity(opts)
+
+	if maxLevel := levels.max(); maxLevel > defaultLevel {
+		opts.Level = zap.NewAtomicLevelAt(zapcore.Level(-maxLevel))
+	}
+
+	return WithLevels(ctrlzap.New(ctrlzap.UseFlagOptions(opts)), defaultLevel, levels)
+}
+
+// verbosity returns logr verbosity corresponding to the zap level defined in the options. Negative values
+// mean that even info messages are not logged.
+func verbosity(opts *ctrlzap.Options) int {
+	switch level := opts.Level.(type) {
+	case nil:
+		if opts.Development {
+			return -int(zapcore.DebugLevel)
+		}
+
+		return -int(zapcore.InfoLevel)
+	case zap.AtomicLevel:
+		return -int(level.Level())
+	case zapcore.Level:
+		return -int(level)
+	}
+
+	return -int(zapcore.InfoLevel)
+}
+
+// WithLevels limits verbosity of the logger and all loggers derived from it. Derived logger uses the level
+// defined for the last of its names found in levels, or the default level when none of them is.
+func WithLevels(log logr.Logger, defaultLevel int, levels Levels) logr.Logger {
+	sink := log.GetSink()
+	if callDepthSink, ok := sink.(logr.CallDepthLogSink); ok {
+		// Skips the frame of levelSink, so that callers are reported correctly.
+		sink = callDepthSink.WithCallDepth(1)
+	}
+
+	return logr.New(&levelSink{sink: sink, levels: levels, level: defaultLevel})
+}
+
+type levelSink struct {
+	sink   logr.LogSink
+	levels Levels
+	level  int
+}
+
+var (
+	_ logr.LogSink          = &levelSink{}
+	_ logr.CallDepthLogSink = &levelSink{}
+)
+
+// Init does nothing, as the wrapped sink has already been initialized by its logger.
+func (s *levelSink) Init(logr.RuntimeInfo) {}
+
+func (s *levelSink) Enabled(level int) bool {
+	return level <= s.level && s.sink.Enabled(level)
+}
+
+func (s *levelSink) Info(level int, msg string, keysAndValues ...any) {
+	s.sink.Info(level, msg, keysAndValues...)
+}
+
+func (s *levelSink) Error(err error, msg string, keysAndValues ...any) {
+	s.sink.Error(err, msg, keysAndValues...)
+}
+
+func (s *levelSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &levelSink{sink: s.sink.WithValues(keysAndValues...), levels: s.levels, level: s.level}
+}
+
+func (s *levelSink) WithName(name string) logr.LogSink {
+	level := s.level
+	if override, found := s.levels[name]; found {
+		level = override
+	}
+
+	return &levelSink{sink: s.sink.WithName(name), levels: s.levels, level: level}
+}
+
+func (s *levelSink) WithCallDepth(depth int) logr.LogSink {
+	sink, ok := s.sink.(logr.CallDepthLogSink)
+	if !ok {
+		return s
+	}
+
+	return &levelSink{sink: sink.WithCallDepth(depth), levels: s.levels, level: s.level}
+}
@@ -0,0 +1,100 @@
+package logging_test
+
+import (
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/logging"
+	"github.com/opendatahub-io/odh-platform/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Logging", test.Unit(), func() {
+
+	Context("verbosity levels", func() {
+
+		It("should parse comma-separated levels", func() {
+			levels := logging.Levels{}
+			Expect(levels.Set("routing=2, authorization-inferenceservice=5,")).To(Succeed())
+
+			Expect(levels).To(Equal(logging.Levels{"routing": 2, "authorization-inferenceservice": 5}))
+			Expect(levels.String()).To(Equal("authorization-inferenceservice=5,routing=2"))
+		})
+
+		DescribeTable("should reject invalid levels",
+			func(value string) {
+				Expect(logging.Levels{}.Set(value)).To(MatchError(ContainSubstring("invalid log level")))
+			},
+			Entry("missing level", "routing"),
+			Entry("missing name", "=2"),
+			Entry("negative level", "routing=-1"),
+			Entry("level out of range", "routing=128"),
+		)
+
+		It("should limit verbosity of named loggers", func() {
+			var lines []string
+
+			sink := funcr.New(func(prefix, _ string) { lines = append(lines, prefix) }, funcr.Options{Verbosity: 10})
+			log := logging.WithLevels(sink, 1, logging.Levels{"routing": 5, "routing-inferenceservice": 0})
+
+			routingLog := log.WithName("controllers").WithName("routing")
+			controllerLog := routingLog.WithValues("controller", "routing").WithName("routing-inferenceservice")
+
+			log.V(1).Info("default")
+			log.V(2).Info("default")
+			routingLog.V(5).Info("capability")
+			routingLog.V(6).Info("capability")
+			controllerLog.Info("controller")
+			controllerLog.V(1).Info("controller")
+
+			Expect(lines).To(Equal([]string{
+				"",
+				"controllers/routing",
+				"controllers/routing/routing-inferenceservice",
+			}))
+		})
+
+		It("should keep logging errors regardless of verbosity", func() {
+			var lines []string
+
+			sink := funcr.New(func(prefix, _ string) { lines = append(lines, prefix) }, funcr.Options{})
+			log := logging.WithLevels(sink, -1, logging.Levels{})
+
+			log.Info("info")
+			log.Error(nil, "error")
+
+			Expect(lines).To(Equal([]string{""}))
+		})
+	})
+
+	Context("object keys", func() {
+
+		It("should identify component without logging its content", func() {
+			component := &unstructured.Unstructured{}
+			component.SetGroupVersionKind(schema.GroupVersionKind{Group: "serving.kserve.io", Version: "v1beta1", Kind: "InferenceService"})
+			component.SetNamespace("test-ns")
+			component.SetName("model")
+			component.SetUID(types.UID("1234"))
+			component.SetAnnotations(map[string]string{"secret": "value"})
+
+			Expect(logging.Component(component)).To(Equal([]any{
+				"gvk", "serving.kserve.io/v1beta1, Kind=InferenceService",
+				"namespace", "test-ns",
+				"name", "model",
+				"ownerUID", types.UID("1234"),
+			}))
+		})
+
+		It("should identify managed resource by reference", func() {
+			resource := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "model-route"}}
+			gvk := schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+
+			Expect(logging.Resource(gvk, resource)).To(Equal([]any{
+				"resource", logging.ResourceRef{GVK: "route.openshift.io/v1, Kind=Route", Namespace: "istio-system", Name: "model-route"},
+			}))
+		})
+	})
+})
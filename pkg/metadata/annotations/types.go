@@ -17,8 +17,16 @@ type Annotation interface {
 // AuthEnabled is an Annotation to enroll given component to authentication
 // and authorization framework provided by Opendatahub Platform. It is used
 // on the component's Custom Resource which is watched by Platform's controller.
+//
+// Setting it to "disabled" opts the component out of the framework, removing all authorization resources
+// created by the Platform for it.
 type AuthEnabled string
 
+// AuthDisabled opts the component out of authentication and authorization framework.
+func AuthDisabled() AuthEnabled {
+	return AuthEnabled("disabled")
+}
+
 func (a AuthEnabled) ApplyToMeta(obj metav1.Object) {
 	addAnnotation(a, obj)
 }
@@ -31,6 +39,120 @@ func (a AuthEnabled) Value() string {
 	return string(a)
 }
 
+// AuthType selects the authentication method used for the component when AuthEnabled is set to "true".
+// Supported values are "kubernetes" (default), "oidc" and "apikey".
+type AuthType string
+
+func (a AuthType) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(a, obj)
+}
+
+func (a AuthType) Key() string {
+	return "security.opendatahub.io/auth-type"
+}
+
+func (a AuthType) Value() string {
+	return string(a)
+}
+
+// RequiredClaims defines claims the authenticated identity has to have to be authorized, e.g. "groups~=admins|data-science".
+// It is used on the component's Custom Resource together with AuthType "oidc".
+type RequiredClaims string
+
+func (r RequiredClaims) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(r, obj)
+}
+
+func (r RequiredClaims) Key() string {
+	return "security.opendatahub.io/required-claims"
+}
+
+func (r RequiredClaims) Value() string {
+	return string(r)
+}
+
+// AllowedGroups defines comma-separated list of groups granted access to the component, e.g. "data-science,admins".
+// It is used on the component's Custom Resource which is watched by Platform's controller.
+type AllowedGroups string
+
+func (a AllowedGroups) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(a, obj)
+}
+
+func (a AllowedGroups) Key() string {
+	return "security.opendatahub.io/allowed-groups"
+}
+
+func (a AllowedGroups) Value() string {
+	return string(a)
+}
+
+// AllowedUsers defines comma-separated list of usernames granted access to the component.
+// It is used on the component's Custom Resource which is watched by Platform's controller.
+type AllowedUsers string
+
+func (a AllowedUsers) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(a, obj)
+}
+
+func (a AllowedUsers) Key() string {
+	return "security.opendatahub.io/allowed-users"
+}
+
+func (a AllowedUsers) Value() string {
+	return string(a)
+}
+
+// AuthCacheTTL overrides the number of seconds results of TokenReview and SubjectAccessReview are cached for.
+// "0" disables caching for the component. It is used on the component's Custom Resource watched by Platform's controller.
+type AuthCacheTTL string
+
+func (a AuthCacheTTL) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(a, obj)
+}
+
+func (a AuthCacheTTL) Key() string {
+	return "security.opendatahub.io/auth-cache-ttl"
+}
+
+func (a AuthCacheTTL) Value() string {
+	return string(a)
+}
+
+// AuthCacheKey overrides Authorino selector resolving the key of cached TokenReview and SubjectAccessReview results.
+// Only selectors identifying the caller are accepted, i.e. the token sent by the caller or the platform default.
+// It is used on the component's Custom Resource watched by Platform's controller.
+type AuthCacheKey string
+
+func (a AuthCacheKey) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(a, obj)
+}
+
+func (a AuthCacheKey) Key() string {
+	return "security.opendatahub.io/auth-cache-key"
+}
+
+func (a AuthCacheKey) Value() string {
+	return string(a)
+}
+
+// UnprotectedPaths overrides the list of requests excluded from authorization defined for the component.
+// It is used on the component's Custom Resource which is watched by Platform's controller.
+// The value is a JSON list of path exclusions, e.g. [{"paths":["/v2/health/ready"],"methods":["GET"]}].
+type UnprotectedPaths string
+
+func (u UnprotectedPaths) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(u, obj)
+}
+
+func (u UnprotectedPaths) Key() string {
+	return "security.opendatahub.io/unprotected-paths"
+}
+
+func (u UnprotectedPaths) Value() string {
+	return string(u)
+}
+
 // AuthorizationGroup defines the group given Authorization configuration belongs to.
 // It is used on Platform's AuthConfig to indicate which Authorization service should
 // be handling the configuration.
@@ -110,6 +232,55 @@ func (r RoutingAddressesExternal) Value() string {
 	return string(r)
 }
 
+// AuthConfigReady reports whether AuthConfig created for the component is ready, i.e. all its hosts are linked
+// to Authorino instance. It is set by the Platform's Authorization controller back to the component's Custom Resource.
+type AuthConfigReady string
+
+func (a AuthConfigReady) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(a, obj)
+}
+
+func (a AuthConfigReady) Key() string {
+	return "security.opendatahub.io/authconfig-ready"
+}
+
+func (a AuthConfigReady) Value() string {
+	return string(a)
+}
+
+// AuthConfigHostsReady exposes the hosts of AuthConfig created for the component which are linked to Authorino instance.
+// It is set by the Platform's Authorization controller back to the component's Custom Resource.
+// Values are delimited by ";".
+type AuthConfigHostsReady string
+
+func (a AuthConfigHostsReady) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(a, obj)
+}
+
+func (a AuthConfigHostsReady) Key() string {
+	return "security.opendatahub.io/authconfig-hosts-ready"
+}
+
+func (a AuthConfigHostsReady) Value() string {
+	return string(a)
+}
+
+// AuthConfigStatus explains why AuthConfig created for the component is not ready, in the form of "Reason: message".
+// It is set by the Platform's Authorization controller back to the component's Custom Resource.
+type AuthConfigStatus string
+
+func (a AuthConfigStatus) ApplyToMeta(obj metav1.Object) {
+	addAnnotation(a, obj)
+}
+
+func (a AuthConfigStatus) Key() string {
+	return "security.opendatahub.io/authconfig-status"
+}
+
+func (a AuthConfigStatus) Value() string {
+	return string(a)
+}
+
 func addAnnotation(annotation Annotation, obj metav1.Object) {
 	existingAnnotations := obj.GetAnnotations()
 	if existingAnnotations == nil {
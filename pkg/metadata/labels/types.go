@@ -131,6 +131,18 @@ func (o OwnerUID) Value() string {
 	return string(o)
 }
 
+// APIKey marks a Secret holding an API key which grants access to the component it is owned by.
+// It is used in conjunction with OwnerName and OwnerKind labels identifying the component's Custom Resource.
+type APIKey string
+
+func (a APIKey) ApplyToMeta(obj metav1.Object) {
+	addLabel(a, obj)
+}
+
+func (a APIKey) Key() string { return "security.opendatahub.io/api-key" }
+
+func (a APIKey) Value() string { return string(a) }
+
 // ExportType is a Label to mark created resources with which export type they were created for.
 // this can either be public or external.
 type ExportType string
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftDetector recognizes applies which restored managed resources changed or deleted outside of the platform.
+// Desired state of each applied resource is remembered, and when the same state is applied again, a new generation
+// (or a new UID, when the resource has been recreated) means the resource had drifted from it.
+type DriftDetector struct {
+	capability Capability
+
+	mu sync.Mutex
+	// applied holds the last applied state of resources, by their owner.
+	applied map[types.NamespacedName]map[resourceKey]appliedState
+}
+
+type resourceKey struct {
+	apiVersion, kind, namespace, name string
+}
+
+type appliedState struct {
+	checksum   [sha256.Size]byte
+	uid        types.UID
+	generation int64
+}
+
+func NewDriftDetector(capability Capability) *DriftDetector {
+	return &DriftDetector{
+		capability: capability,
+		applied:    map[types.NamespacedName]map[resourceKey]appliedState{},
+	}
+}
+
+// Observe returns a function to be called with the desired and applied state of each resource managed for the owner.
+func (d *DriftDetector) Observe(owner client.Object) func(desired, applied *unstructured.Unstructured) {
+	ownerKey := client.ObjectKeyFromObject(owner)
+
+	return func(desired, applied *unstructured.Unstructured) {
+		data, errJSON := desired.MarshalJSON()
+		if errJSON != nil {
+			return
+		}
+
+		key := resourceKey{
+			apiVersion: applied.GetAPIVersion(),
+			kind:       applied.GetKind(),
+			namespace:  applied.GetNamespace(),
+			name:       applied.GetName(),
+		}
+		current := appliedState{
+			checksum:   sha256.Sum256(data),
+			uid:        applied.GetUID(),
+			generation: applied.GetGeneration(),
+		}
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		resources := d.applied[ownerKey]
+		if resources == nil {
+			resources = map[resourceKey]appliedState{}
+			d.applied[ownerKey] = resources
+		}
+
+		previous, found := resources[key]
+		resources[key] = current
+
+		if found && previous.checksum == current.checksum &&
+			(previous.uid != current.uid || previous.generation < current.generation) {
+			driftCorrections.WithLabelValues(string(d.capability), owner.GetObjectKind().GroupVersionKind().Kind,
+				namespaces.value(owner.GetNamespace(), componentKey(d.capability, owner)), applied.GroupVersionKind().GroupKind().String()).Inc()
+		}
+	}
+}
+
+// Forget drops the state of resources managed for the owner, e.g. when it has been deleted.
+func (d *DriftDetector) Forget(owner types.NamespacedName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.applied, owner)
+}
@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const labelSeparator = "\xff"
+
+// objectGauge is a gauge which series are sums of values contributed by individual objects, so that these values
+// can be replaced when the object changes and withdrawn when it is gone. Each object can contribute several parts,
+// e.g. counts of different resource kinds, which are replaced independently. Series dropping to zero are removed,
+// so that gauges do not keep series of deleted components.
+type objectGauge struct {
+	gauge *prometheus.GaugeVec
+
+	mu sync.Mutex
+	// contributions holds values of series (identified by joined label values) contributed by parts of objects.
+	contributions map[string]map[string]map[string]float64
+	totals        map[string]float64
+}
+
+func newObjectGauge(opts prometheus.GaugeOpts, labelNames ...string) *objectGauge {
+	return &objectGauge{
+		gauge:         prometheus.NewGaugeVec(opts, labelNames),
+		contributions: map[string]map[string]map[string]float64{},
+		totals:        map[string]float64{},
+	}
+}
+
+// set replaces values contributed by the part of the object with value of 1 for each of the series.
+func (g *objectGauge) set(object, part string, series map[string][]string) {
+	values := make(map[string]float64, len(series))
+	for _, labels := range series {
+		values[strings.Join(labels, labelSeparator)] = 1
+	}
+
+	g.replace(object, part, values)
+}
+
+// setValue replaces values contributed by the part of the object with the value of a single series.
+func (g *objectGauge) setValue(object, part string, labels []string, value float64) {
+	values := map[string]float64{}
+	if value != 0 {
+		values[strings.Join(labels, labelSeparator)] = value
+	}
+
+	g.replace(object, part, values)
+}
+
+func (g *objectGauge) replace(object, part string, values map[string]float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	parts := g.contributions[object]
+	if parts == nil {
+		parts = map[string]map[string]float64{}
+		g.contributions[object] = parts
+	}
+
+	g.withdraw(parts[part])
+
+	if len(values) == 0 {
+		delete(parts, part)
+
+		if len(parts) == 0 {
+			delete(g.contributions, object)
+		}
+
+		return
+	}
+
+	parts[part] = values
+
+	for series, value := range values {
+		g.totals[series] += value
+		g.gauge.WithLabelValues(strings.Split(series, labelSeparator)...).Set(g.totals[series])
+	}
+}
+
+// forget withdraws all values contributed by the object.
+func (g *objectGauge) forget(object string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, values := range g.contributions[object] {
+		g.withdraw(values)
+	}
+
+	delete(g.contributions, object)
+}
+
+// forgetPrefix withdraws all values contributed by objects which keys start with the prefix.
+func (g *objectGauge) forgetPrefix(prefix string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for object, parts := range g.contributions {
+		if !strings.HasPrefix(object, prefix) {
+			continue
+		}
+
+		for _, values := range parts {
+			g.withdraw(values)
+		}
+
+		delete(g.contributions, object)
+	}
+}
+
+// withdraw subtracts the values from series totals. It has to be called with the lock held.
+func (g *objectGauge) withdraw(values map[string]float64) {
+	for series, value := range values {
+		g.totals[series] -= value
+
+		labels := strings.Split(series, labelSeparator)
+		if g.totals[series] == 0 {
+			delete(g.totals, series)
+			g.gauge.DeleteLabelValues(labels...)
+
+			continue
+		}
+
+		g.gauge.WithLabelValues(labels...).Set(g.totals[series])
+	}
+}
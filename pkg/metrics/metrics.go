@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Capability is a platform capability recorded metrics relate to.
+type Capability string
+
+const (
+	Routing       Capability = "routing"
+	Authorization Capability = "authorization"
+)
+
+const metricsNamespace = "odh_platform"
+
+// NoAuthorization is recorded as the auth type of components which opted out of authorization.
+const NoAuthorization = "none"
+
+//nolint:gochecknoglobals //reason: collectors are registered once with the controller-runtime registry
+var (
+	routingComponents = newObjectGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "routing_components",
+		Help:      "Number of components with the given export mode enabled.",
+	}, "kind", "namespace", "export_mode")
+
+	authorizationComponents = newObjectGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "authorization_components",
+		Help:      "Number of components protected using the given auth type.",
+	}, "kind", "namespace", "auth_type")
+
+	managedResources = newObjectGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "managed_resources",
+		Help:      "Number of resources managed by the platform for components, by their kind.",
+	}, "capability", "kind", "namespace", "resource")
+
+	exportedHosts = newObjectGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "exported_hosts",
+		Help:      "Number of hosts published for components.",
+	}, "kind", "namespace")
+
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of failed reconciliation steps.",
+	}, []string{"capability", "kind", "namespace", "step"})
+
+	driftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "drift_corrections_total",
+		Help:      "Number of managed resources restored after being changed or deleted outside of the platform.",
+	}, []string{"capability", "kind", "namespace", "resource"})
+
+	addressPublication = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "address_publication_seconds",
+		Help:      "Time from the creation of a component to its addresses being published.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"kind", "namespace"})
+
+	namespaces = newNamespaceGuard(DefaultMaxNamespaces)
+)
+
+func init() { //nolint:gochecknoinits //reason: registering collectors before the manager serves metrics
+	ctrlmetrics.Registry.MustRegister(
+		routingComponents.gauge,
+		authorizationComponents.gauge,
+		managedResources.gauge,
+		exportedHosts.gauge,
+		reconcileErrors,
+		driftCorrections,
+		addressPublication,
+	)
+}
+
+// SetExportModes records export modes enabled for the component.
+func SetExportModes(component client.Object, exportModes []string) {
+	series := make(map[string][]string, len(exportModes))
+	for _, exportMode := range exportModes {
+		series[exportMode] = componentLabels(Routing, component, exportMode)
+	}
+
+	routingComponents.set(componentKey(Routing, component), "", series)
+}
+
+// SetAuthType records the auth type used to protect the component.
+func SetAuthType(component client.Object, authType string) {
+	authorizationComponents.set(componentKey(Authorization, component), "", map[string][]string{
+		authType: componentLabels(Authorization, component, authType),
+	})
+}
+
+// SetExportedHosts records the number of hosts published for the component.
+func SetExportedHosts(component client.Object, count int) {
+	exportedHosts.setValue(componentKey(Routing, component), "", componentLabels(Routing, component), float64(count))
+}
+
+// SetManagedResources records the number of resources of the given kind managed for the component.
+func SetManagedResources(capability Capability, component client.Object, resource schema.GroupKind, count int) {
+	labels := append([]string{string(capability)}, componentLabels(capability, component, resource.String())...)
+
+	managedResources.setValue(componentKey(capability, component), resource.String(), labels, float64(count))
+}
+
+// ReconcileFailed records failure of the reconciliation step for the component.
+func ReconcileFailed(capability Capability, component client.Object, step string) {
+	labels := append([]string{string(capability)}, componentLabels(capability, component, step)...)
+
+	reconcileErrors.WithLabelValues(labels...).Inc()
+}
+
+// AddressesPublished records the time it took to publish addresses of the component since its creation.
+func AddressesPublished(component client.Object, now time.Time) {
+	created := component.GetCreationTimestamp()
+	if created.IsZero() {
+		return
+	}
+
+	addressPublication.WithLabelValues(componentLabels(Routing, component)...).Observe(now.Sub(created.Time).Seconds())
+}
+
+// ForgetComponent withdraws the state recorded for the component of the given kind, e.g. when it has been deleted.
+func ForgetComponent(capability Capability, kind, namespace, name string) {
+	key := capability.key(kind, namespace, name)
+
+	managedResources.forget(key)
+
+	switch capability {
+	case Routing:
+		routingComponents.forget(key)
+		exportedHosts.forget(key)
+	case Authorization:
+		authorizationComponents.forget(key)
+	}
+
+	if namespaces.release(namespace, key) {
+		forgetNamespace(namespace)
+	}
+}
+
+// ForgetKind withdraws the state recorded for all components of the given kind, e.g. when the capability
+// is no longer enabled for them.
+func ForgetKind(capability Capability, kind string) {
+	prefix := string(capability) + "/" + kind + "/"
+
+	managedResources.forgetPrefix(prefix)
+
+	switch capability {
+	case Routing:
+		routingComponents.forgetPrefix(prefix)
+		exportedHosts.forgetPrefix(prefix)
+	case Authorization:
+		authorizationComponents.forgetPrefix(prefix)
+	}
+
+	for _, namespace := range namespaces.releasePrefix(prefix) {
+		forgetNamespace(namespace)
+	}
+}
+
+// forgetNamespace removes series of the released namespace which are not withdrawn with individual components,
+// so that the number of series stays bounded when namespaces come and go.
+func forgetNamespace(namespace string) {
+	labels := prometheus.Labels{"namespace": namespace}
+
+	reconcileErrors.DeletePartialMatch(labels)
+	driftCorrections.DeletePartialMatch(labels)
+	addressPublication.DeletePartialMatch(labels)
+}
+
+func (c Capability) key(kind, namespace, name string) string {
+	return string(c) + "/" + kind + "/" + namespace + "/" + name
+}
+
+func componentKey(capability Capability, component client.Object) string {
+	return capability.key(component.GetObjectKind().GroupVersionKind().Kind, component.GetNamespace(), component.GetName())
+}
+
+// componentLabels returns kind and (guarded) namespace label values of the component, followed by the given values.
+func componentLabels(capability Capability, component client.Object, values ...string) []string {
+	namespace := namespaces.value(component.GetNamespace(), componentKey(capability, component))
+
+	return append([]string{component.GetObjectKind().GroupVersionKind().Kind, namespace}, values...)
+}
@@ -0,0 +1,230 @@
+package metrics_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/metrics"
+	"github.com/opendatahub-io/odh-platform/test"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var _ = Describe("Platform metrics", test.Unit(), func() {
+
+	// Each of the tests uses its own component kind, as collectors are shared.
+	component := func(kind, namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: kind})
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+
+		return obj
+	}
+
+	It("should count components by export mode and withdraw deleted ones", func() {
+		// given
+		first := component("ExportModes", "ns-a", "first")
+		second := component("ExportModes", "ns-a", "second")
+
+		// when
+		metrics.SetExportModes(first, []string{"public", "external"})
+		metrics.SetExportModes(second, []string{"public"})
+
+		// then
+		Expect(metricValue("odh_platform_routing_components", "ExportModes", "export_mode", "public")).To(Equal(2.0))
+		Expect(metricValue("odh_platform_routing_components", "ExportModes", "export_mode", "external")).To(Equal(1.0))
+
+		// when
+		metrics.SetExportModes(second, []string{"external"})
+		metrics.ForgetComponent(metrics.Routing, "ExportModes", "ns-a", "first")
+
+		// then
+		Expect(metricValue("odh_platform_routing_components", "ExportModes", "export_mode", "public")).To(BeZero())
+		Expect(hasSeries("odh_platform_routing_components", "ExportModes", "export_mode", "public")).To(BeFalse())
+		Expect(metricValue("odh_platform_routing_components", "ExportModes", "export_mode", "external")).To(Equal(1.0))
+	})
+
+	It("should count managed resources by their kind", func() {
+		// given
+		owner := component("Managed", "ns-a", "owner")
+		virtualService := schema.GroupKind{Group: "networking.istio.io", Kind: "VirtualService"}
+
+		// when
+		metrics.SetManagedResources(metrics.Routing, owner, virtualService, 2)
+
+		// then
+		Expect(metricValue("odh_platform_managed_resources", "Managed", "resource", "VirtualService.networking.istio.io")).To(Equal(2.0))
+
+		// when
+		metrics.SetManagedResources(metrics.Routing, owner, virtualService, 0)
+
+		// then
+		Expect(hasSeries("odh_platform_managed_resources", "Managed", "resource", "VirtualService.networking.istio.io")).To(BeFalse())
+	})
+
+	It("should withdraw components of the deactivated kind", func() {
+		// given
+		metrics.SetAuthType(component("Deactivated", "ns-a", "first"), "oidc")
+		metrics.SetAuthType(component("Deactivated", "ns-b", "second"), "oidc")
+
+		// when
+		metrics.ForgetKind(metrics.Authorization, "Deactivated")
+
+		// then
+		Expect(hasSeries("odh_platform_authorization_components", "Deactivated", "auth_type", "oidc")).To(BeFalse())
+	})
+
+	It("should report namespaces exceeding the limit as other", func() {
+		// given
+		metrics.LimitNamespaces(1)
+		DeferCleanup(metrics.LimitNamespaces, metrics.DefaultMaxNamespaces)
+
+		// when
+		metrics.SetExportedHosts(component("Guarded", "ns-a", "first"), 1)
+		metrics.SetExportedHosts(component("Guarded", "ns-b", "second"), 2)
+		metrics.SetExportedHosts(component("Guarded", "ns-c", "third"), 3)
+
+		// then
+		Expect(metricValue("odh_platform_exported_hosts", "Guarded", "namespace", "ns-a")).To(Equal(1.0))
+		Expect(metricValue("odh_platform_exported_hosts", "Guarded", "namespace", metrics.OtherNamespaces)).To(Equal(5.0))
+	})
+
+	It("should release namespaces once all their components are forgotten", func() {
+		// given
+		metrics.LimitNamespaces(1)
+		DeferCleanup(metrics.LimitNamespaces, metrics.DefaultMaxNamespaces)
+
+		metrics.SetExportedHosts(component("Released", "ns-a", "first"), 1)
+		metrics.ReconcileFailed(metrics.Routing, component("Released", "ns-a", "first"), "exported-services")
+
+		// when
+		metrics.ForgetComponent(metrics.Routing, "Released", "ns-a", "first")
+		metrics.SetExportedHosts(component("Released", "ns-b", "second"), 2)
+
+		// then
+		Expect(metricValue("odh_platform_exported_hosts", "Released", "namespace", "ns-b")).To(Equal(2.0))
+		Expect(hasSeries("odh_platform_exported_hosts", "Released", "namespace", metrics.OtherNamespaces)).To(BeFalse())
+		Expect(hasSeries("odh_platform_reconcile_errors_total", "Released", "namespace", "ns-a")).To(BeFalse())
+	})
+
+	It("should record time to publish addresses since the component creation", func() {
+		// given
+		published := component("Published", "ns-a", "first")
+		// creation timestamp has a precision of seconds
+		created := time.Now().Add(-3 * time.Second).Truncate(time.Second)
+		published.SetCreationTimestamp(metav1.NewTime(created))
+
+		// when
+		metrics.AddressesPublished(published, created.Add(3*time.Second))
+
+		// then
+		Expect(histogramSum("odh_platform_address_publication_seconds", "Published")).To(BeNumerically("~", 3, 0.01))
+	})
+
+	Context("drift detection", func() {
+
+		resource := func(generation int64, uid types.UID, host string) *unstructured.Unstructured {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "VirtualService"})
+			obj.SetNamespace("ns-a")
+			obj.SetName("route")
+			obj.SetGeneration(generation)
+			obj.SetUID(uid)
+			_ = unstructured.SetNestedStringSlice(obj.Object, []string{host}, "spec", "hosts")
+
+			return obj
+		}
+
+		It("should count re-applied desired state of modified resources as drift correction", func() {
+			// given
+			detector := metrics.NewDriftDetector(metrics.Routing)
+			observe := detector.Observe(component("Drifted", "ns-a", "owner"))
+			desired := resource(0, "", "example.com")
+
+			// when
+			observe(desired, resource(1, "uid-1", "example.com"))
+			observe(desired, resource(1, "uid-1", "example.com")) // no changes
+			observe(desired, resource(3, "uid-1", "example.com")) // modified and restored
+			observe(desired, resource(1, "uid-2", "example.com")) // deleted and recreated
+
+			// then
+			Expect(metricValue("odh_platform_drift_corrections_total", "Drifted", "resource", "VirtualService.networking.istio.io")).To(Equal(2.0))
+		})
+
+		It("should not count changes of the desired state as drift correction", func() {
+			// given
+			detector := metrics.NewDriftDetector(metrics.Routing)
+			observe := detector.Observe(component("Changed", "ns-a", "owner"))
+
+			// when
+			observe(resource(0, "", "example.com"), resource(1, "uid-1", "example.com"))
+			observe(resource(0, "", "example.org"), resource(2, "uid-1", "example.org"))
+
+			// then
+			Expect(hasSeries("odh_platform_drift_corrections_total", "Changed", "resource", "VirtualService.networking.istio.io")).To(BeFalse())
+		})
+	})
+})
+
+// metricValue returns the value of the gauge or counter series of the component kind having the given label value.
+func metricValue(name, kind, labelName, labelValue string) float64 {
+	var sum float64
+
+	for _, metric := range findSeries(name, kind, labelName, labelValue) {
+		if metric.GetGauge() != nil {
+			sum += metric.GetGauge().GetValue()
+		}
+
+		if metric.GetCounter() != nil {
+			sum += metric.GetCounter().GetValue()
+		}
+	}
+
+	return sum
+}
+
+func hasSeries(name, kind, labelName, labelValue string) bool {
+	return len(findSeries(name, kind, labelName, labelValue)) > 0
+}
+
+func histogramSum(name, kind string) float64 {
+	var sum float64
+
+	for _, metric := range findSeries(name, kind, "kind", kind) {
+		sum += metric.GetHistogram().GetSampleSum()
+	}
+
+	return sum
+}
+
+func findSeries(name, kind, labelName, labelValue string) []*dto.Metric {
+	families, err := ctrlmetrics.Registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	var found []*dto.Metric
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["kind"] == kind && labels[labelName] == labelValue {
+				found = append(found, metric)
+			}
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"strings"
+	"sync"
+)
+
+// DefaultMaxNamespaces is the default number of distinct namespaces used as label values.
+const DefaultMaxNamespaces = 100
+
+// OtherNamespaces is the namespace label value of components in namespaces exceeding the limit.
+const OtherNamespaces = "_other"
+
+// LimitNamespaces sets the number of distinct namespaces used as label values, guarding the cardinality of metrics
+// in clusters with many namespaces. Components in namespaces observed while the limit is reached are labelled
+// with OtherNamespaces. Namespaces are released once all their components are forgotten. Zero disables the namespace
+// breakdown altogether.
+func LimitNamespaces(maxNamespaces int) {
+	namespaces.limit(maxNamespaces)
+}
+
+type namespaceGuard struct {
+	mu  sync.Mutex
+	max int
+	// components holds keys of components recorded in each of the admitted namespaces.
+	components map[string]map[string]struct{}
+}
+
+func newNamespaceGuard(maxNamespaces int) *namespaceGuard {
+	return &namespaceGuard{max: maxNamespaces, components: map[string]map[string]struct{}{}}
+}
+
+func (g *namespaceGuard) limit(maxNamespaces int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.max = maxNamespaces
+	g.components = map[string]map[string]struct{}{}
+}
+
+// value returns the label value for the namespace of the component, admitting the namespace when the limit
+// has not been reached yet.
+func (g *namespaceGuard) value(namespace, component string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if components, admitted := g.components[namespace]; admitted {
+		components[component] = struct{}{}
+
+		return namespace
+	}
+
+	if len(g.components) >= g.max {
+		return OtherNamespaces
+	}
+
+	g.components[namespace] = map[string]struct{}{component: {}}
+
+	return namespace
+}
+
+// release forgets the component and reports whether its namespace has been released as a result.
+func (g *namespaceGuard) release(namespace, component string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.releaseMatching(namespace, func(key string) bool { return key == component })
+}
+
+// releasePrefix forgets components which keys start with the prefix and returns released namespaces.
+func (g *namespaceGuard) releasePrefix(prefix string) []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var released []string
+
+	for namespace := range g.components {
+		if g.releaseMatching(namespace, func(key string) bool { return strings.HasPrefix(key, prefix) }) {
+			released = append(released, namespace)
+		}
+	}
+
+	return released
+}
+
+// releaseMatching has to be called with the lock held.
+func (g *namespaceGuard) releaseMatching(namespace string, matches func(component string) bool) bool {
+	components, admitted := g.components[namespace]
+	if !admitted {
+		return false
+	}
+
+	for component := range components {
+		if matches(component) {
+			delete(components, component)
+		}
+	}
+
+	if len(components) > 0 {
+		return false
+	}
+
+	delete(g.components, namespace)
+
+	return true
+}
@@ -0,0 +1,36 @@
+package platform
+
+import (
+	"fmt"
+
+	"github.com/opendatahub-io/odh-platform/pkg/config"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// LoadProtectedResources loads and validates protected resources defined in the given file.
+func LoadProtectedResources(configPath string) ([]ProtectedResource, error) {
+	resources, err := config.LoadEntries[ProtectedResource](configPath)
+	if err != nil {
+		return nil, err //nolint:wrapcheck //reason: already points at the file
+	}
+
+	if errs := ValidateProtectedResources(resources, field.NewPath("")); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid entries in [%s]: %w", configPath, errs.ToAggregate())
+	}
+
+	return resources, nil
+}
+
+// LoadRoutingTargets loads and validates routing targets defined in the given file.
+func LoadRoutingTargets(configPath string) ([]RoutingTarget, error) {
+	targets, err := config.LoadEntries[RoutingTarget](configPath)
+	if err != nil {
+		return nil, err //nolint:wrapcheck //reason: already points at the file
+	}
+
+	if errs := ValidateRoutingTargets(targets, field.NewPath("")); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid entries in [%s]: %w", configPath, errs.ToAggregate())
+	}
+
+	return targets, nil
+}
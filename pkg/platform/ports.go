@@ -0,0 +1,65 @@
+package platform
+
+import (
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ServicesSelecting returns Services routing traffic to the workload identified by the workload selector,
+// i.e. those whose spec.selector is satisfied by the labels of the workload selector. Services without
+// a selector are skipped, as their endpoints are managed manually.
+func ServicesSelecting(services []corev1.Service, workloadSelector map[string]string) []corev1.Service {
+	var selecting []corev1.Service
+
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(workloadSelector)) {
+			selecting = append(selecting, svc)
+		}
+	}
+
+	return selecting
+}
+
+// DiscoverPorts returns target ports of Service ports matching Ports by name or number, or of all
+// Service ports when Ports are not defined. Service ports with named target ports are returned as unresolved,
+// as they can only be resolved using Pod definitions.
+func (p ProtectedResource) DiscoverPorts(services []corev1.Service) ([]string, []string) {
+	var targetPorts []int
+
+	var unresolved []string
+
+	for _, svc := range services {
+		for _, svcPort := range svc.Spec.Ports {
+			if len(p.Ports) > 0 && !slices.Contains(p.Ports, svcPort.Name) && !slices.Contains(p.Ports, strconv.Itoa(int(svcPort.Port))) {
+				continue
+			}
+
+			switch {
+			case svcPort.TargetPort.Type == intstr.String:
+				unresolved = append(unresolved, svc.Name+"/"+svcPort.Name)
+			case svcPort.TargetPort.IntValue() == 0:
+				targetPorts = append(targetPorts, int(svcPort.Port))
+			default:
+				targetPorts = append(targetPorts, svcPort.TargetPort.IntValue())
+			}
+		}
+	}
+
+	// Sorted to keep rules stable across reconciliations
+	slices.Sort(targetPorts)
+
+	ports := make([]string, 0, len(targetPorts))
+	for _, port := range slices.Compact(targetPorts) {
+		ports = append(ports, strconv.Itoa(port))
+	}
+
+	return ports, unresolved
+}
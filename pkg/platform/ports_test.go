@@ -0,0 +1,84 @@
+package platform_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"github.com/opendatahub-io/odh-platform/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Port discovery", test.Unit(), func() {
+
+	services := []corev1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "predictor"},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080)},
+					{Name: "grpc", Port: 8081},
+					{Name: "metrics", Port: 9090, TargetPort: intstr.FromString("metrics")},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "predictor-private"},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 8080, TargetPort: intstr.FromInt32(8080)},
+				},
+			},
+		},
+	}
+
+	It("should only select services routing traffic to the workload", func() {
+		// given
+		workloadSelector := map[string]string{"component": "predictor", "app": "my-model"}
+		candidates := []corev1.Service{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "selecting-subset", Labels: map[string]string{"unrelated": "true"}},
+				Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "my-model"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "labeled-only", Labels: workloadSelector},
+				Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "other-model"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "selecting-more", Labels: workloadSelector},
+				Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "my-model", "version": "v2"}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "without-selector", Labels: workloadSelector},
+			},
+		}
+
+		// when
+		selecting := platform.ServicesSelecting(candidates, workloadSelector)
+
+		// then
+		Expect(selecting).To(HaveExactElements(HaveField("Name", "selecting-subset")))
+	})
+
+	It("should resolve target ports of all service ports when ports are not defined", func() {
+		// when
+		ports, unresolved := platform.ProtectedResource{}.DiscoverPorts(services)
+
+		// then
+		Expect(ports).To(HaveExactElements("8080", "8081"))
+		Expect(unresolved).To(ConsistOf("predictor/metrics"))
+	})
+
+	It("should resolve target ports of service ports matching defined ports by name or number", func() {
+		// given
+		protectedResource := platform.ProtectedResource{Ports: []string{"grpc", "80"}}
+
+		// when
+		ports, unresolved := protectedResource.DiscoverPorts(services)
+
+		// then
+		Expect(ports).To(HaveExactElements("8080", "8081"))
+		Expect(unresolved).To(BeEmpty())
+	})
+})
@@ -0,0 +1,43 @@
+package platform
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RequirementsAsMatchLabels converts the requirements to labels which have to match, for selectors which do not
+// support set-based requirements, such as workload selectors of Istio policies. Only "In" requirements with
+// a single value have an equivalent label to match.
+func RequirementsAsMatchLabels(requirements []metav1.LabelSelectorRequirement) (map[string]string, error) {
+	matchLabels := make(map[string]string, len(requirements))
+
+	for _, requirement := range requirements {
+		if !isMatchLabelRequirement(requirement) {
+			return nil, fmt.Errorf("requirement %q has no equivalent in selectors matching labels only, "+
+				"use %q operator with a single value instead", formatRequirement(requirement), metav1.LabelSelectorOpIn)
+		}
+
+		if existing, found := matchLabels[requirement.Key]; found && existing != requirement.Values[0] {
+			return nil, fmt.Errorf("requirements for label %q expect both %q and %q values", requirement.Key, existing, requirement.Values[0])
+		}
+
+		matchLabels[requirement.Key] = requirement.Values[0]
+	}
+
+	return matchLabels, nil
+}
+
+func isMatchLabelRequirement(requirement metav1.LabelSelectorRequirement) bool {
+	return requirement.Operator == metav1.LabelSelectorOpIn && len(requirement.Values) == 1
+}
+
+func formatRequirement(requirement metav1.LabelSelectorRequirement) string {
+	switch requirement.Operator {
+	case metav1.LabelSelectorOpExists, metav1.LabelSelectorOpDoesNotExist:
+		return fmt.Sprintf("%s %s", requirement.Key, requirement.Operator)
+	}
+
+	return fmt.Sprintf("%s %s (%s)", requirement.Key, requirement.Operator, strings.Join(requirement.Values, ", "))
+}
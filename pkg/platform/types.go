@@ -1,11 +1,19 @@
+// +kubebuilder:object:generate=true
 package platform
 
-import "k8s.io/apimachinery/pkg/runtime/schema"
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
 
 // ResourceReference is a reference to a Kubernetes resource which Platform uses to enable certain capabilities.
 // These custom resources serve as single point of configuration for enabling given capability for the component.
 type ResourceReference struct {
 	// GroupVersionKind specifies the group, version, and kind of the resource.
+	// As it is decoded regardless of the case of its keys, its schema is not enforced.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
 	schema.GroupVersionKind `json:"gvk,omitempty"`
 	// Resources is the type of resource being protected in a plural form, e.g., "pods", "services".
 	Resources string `json:"resources,omitempty"`
@@ -22,6 +30,10 @@ type RoutingTarget struct {
 	// go expressions are handled in the selector key and value to set dynamic values from the current ResourceReference;
 	// e.g. "routing.opendatahub.io/{{.kind}}": "{{.metadata.name}}", // > "routing.opendatahub.io/Service": "MyService"
 	ServiceSelector map[string]string `json:"serviceSelector,omitempty"`
+	// ServiceMatchExpressions are set-based requirements ("In", "NotIn", "Exists", "DoesNotExist") the Service
+	// has to match in addition to ServiceSelector, e.g. to exclude a transformer Service sharing labels with the predictor.
+	// Keys and values are resolved the same way as ServiceSelector.
+	ServiceMatchExpressions []metav1.LabelSelectorRequirement `json:"serviceMatchExpressions,omitempty"`
 }
 
 func (r RoutingTarget) GetResourceReference() ResourceReference {
@@ -40,13 +52,112 @@ type ProtectedResource struct {
 	// go expressions are handled in the selector key and value to set dynamic values from the current ResourceReference;
 	// e.g. "routing.opendatahub.io/{{.kind}}": "{{.metadata.name}}", // > "routing.opendatahub.io/Service": "MyService"
 	WorkloadSelector map[string]string `json:"workloadSelector,omitempty"`
+	// WorkloadMatchExpressions are requirements the workload has to match in addition to WorkloadSelector.
+	// Istio policies select workloads by matching labels only, so just "In" requirements with a single value
+	// are supported. Keys and values are resolved the same way as WorkloadSelector.
+	WorkloadMatchExpressions []metav1.LabelSelectorRequirement `json:"workloadMatchExpressions,omitempty"`
 	// HostPaths defines paths in custom resource where hosts for this component are defined.
+	// Paths are either dot-separated field names, e.g. "status.url", or JSONPath expressions,
+	// e.g. "status.addresses[*].url", resolving to strings or lists of strings.
 	HostPaths []string `json:"hostPaths,omitempty"` // TODO(mvp): should we switch to annotations like in routing?
 	// Ports is a list of network ports associated with the resource that require protection.
 	// These ports in conjunction with hosts are subject to the authorization policies defined for the workload.
+	// When PortDiscovery is enabled, entries can also refer to Service ports by name.
 	Ports []string `json:"ports,omitempty"`
+	// PortDiscovery enables resolving Ports from Services whose selector is satisfied by the resolved WorkloadSelector.
+	// Service ports matching Ports by name or number (or all of them, when Ports are not defined) are protected
+	// using their target ports.
+	PortDiscovery bool `json:"portDiscovery,omitempty"`
+	// AccessCheck defines the Kubernetes RBAC check performed against the protected resource
+	// to authorize the caller.
+	AccessCheck AccessCheck `json:"accessCheck,omitempty"`
+	// UnprotectedPaths defines requests which are not subject to authorization, such as health or metrics endpoints.
+	// When not defined, DefaultUnprotectedPaths are used. Empty list means all requests are subject to authorization.
+	// It can be overridden for an individual resource instance using "security.opendatahub.io/unprotected-paths" annotation.
+	UnprotectedPaths []PathExclusion `json:"unprotectedPaths,omitempty"`
+	// PeerAuthentication, when defined, enables management of workload-scoped Istio PeerAuthentication for
+	// the workload selected using WorkloadSelector. When not defined, namespace or mesh-wide mTLS settings apply.
+	PeerAuthentication *MTLSConfig `json:"peerAuthentication,omitempty"`
+	// IdentityHeaders maps request headers passed to the workload to attributes of the authenticated identity, e.g.
+	// {"x-forwarded-user": "username", "x-forwarded-groups": "groups", "x-forwarded-sub": "sub"}.
+	// Values sent by the caller are overwritten, so the headers can be trusted. When not defined, no headers are passed.
+	IdentityHeaders map[string]string `json:"identityHeaders,omitempty"`
+	// EnforcementScope narrows down requests subject to authorization. When not defined, all requests to Ports are subject to it.
+	// Requests carrying identity headers are always subject to authorization.
+	EnforcementScope *EnforcementScope `json:"enforcementScope,omitempty"`
 }
 
 func (p ProtectedResource) GetResourceReference() ResourceReference {
 	return p.ResourceReference
 }
+
+// GetUnprotectedPaths returns configured path exclusions or DefaultUnprotectedPaths when not defined.
+func (p ProtectedResource) GetUnprotectedPaths() []PathExclusion {
+	if p.UnprotectedPaths == nil {
+		return DefaultUnprotectedPaths()
+	}
+
+	return p.UnprotectedPaths
+}
+
+// PathExclusion defines requests excluded from authorization.
+type PathExclusion struct {
+	// Paths is a list of request paths. Exact, prefix ("/metrics/*") and suffix ("*/ready") matches are supported.
+	Paths []string `json:"paths"`
+	// Methods optionally restricts the exclusion to the given HTTP methods only, e.g. "GET".
+	// When empty, requests using any method are excluded.
+	Methods []string `json:"methods,omitempty"`
+}
+
+// DefaultUnprotectedPaths returns paths excluded from authorization when the ProtectedResource does not define its own.
+func DefaultUnprotectedPaths() []PathExclusion {
+	return []PathExclusion{
+		{
+			Paths: []string{
+				"/healthz",
+				"/debug/pprof/",
+				"/metrics",
+				"/wait-for-drain",
+			},
+		},
+	}
+}
+
+// EnforcementScope defines which requests to the workload are subject to authorization, so that e.g. in-mesh
+// service-to-service traffic can rely on mTLS identity instead.
+type EnforcementScope struct {
+	// ExportedHostsOnly limits authorization to requests for hosts defined in HostPaths or in external and public
+	// addresses of the resource. When no hosts are found, all requests are subject to authorization.
+	ExportedHostsOnly bool `json:"exportedHostsOnly,omitempty"`
+	// ExemptPrincipals lists mTLS identities of callers exempt from authorization, e.g. "cluster.local/ns/monitoring/sa/prometheus".
+	ExemptPrincipals []string `json:"exemptPrincipals,omitempty"`
+	// ExemptNamespaces lists namespaces of in-mesh callers exempt from authorization.
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
+}
+
+// MTLSConfig defines mutual TLS modes of the workload. Supported modes are "UNSET", "DISABLE", "PERMISSIVE" and "STRICT".
+type MTLSConfig struct {
+	// Mode applies to all ports of the workload. Defaults to "UNSET", which inherits the mode from namespace or mesh.
+	Mode string `json:"mode,omitempty"`
+	// PortModes overrides the mode for the given ports, e.g. {"8080": "PERMISSIVE"}.
+	PortModes map[string]string `json:"portModes,omitempty"`
+}
+
+// AccessCheck defines the attributes of SubjectAccessReview performed for the caller against the protected resource instance.
+// Group, resource (plural), namespace and name are derived from the protected resource itself.
+type AccessCheck struct {
+	// Verb is the Kubernetes API verb the caller needs to be allowed to perform on the protected resource.
+	// Defaults to "get".
+	Verb string `json:"verb,omitempty"`
+	// SubResource is the subresource of the protected resource the caller needs to be allowed to access, e.g. "proxy".
+	SubResource string `json:"subresource,omitempty"`
+}
+
+// GetVerb returns the configured verb or "get" when not defined.
+func (a AccessCheck) GetVerb() string {
+	if a.Verb == "" {
+		return "get"
+	}
+
+	return a.Verb
+}
@@ -0,0 +1,194 @@
+package platform
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/odh-platform/pkg/config"
+	"github.com/opendatahub-io/odh-platform/pkg/spi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateRoutingTargets checks the routing targets, which have to refer to distinct resource kinds.
+func ValidateRoutingTargets(targets []RoutingTarget, path *field.Path) field.ErrorList {
+	refs := make([]ResourceReference, len(targets))
+	errs := field.ErrorList{}
+
+	for i := range targets {
+		refs[i] = targets[i].ResourceReference
+		errs = append(errs, validateSelectorExpressions(targets[i].ServiceSelector, path.Index(i).Child("serviceSelector"))...)
+		errs = append(errs, validateSelectorRequirements(targets[i].ServiceMatchExpressions, false, path.Index(i).Child("serviceMatchExpressions"))...)
+	}
+
+	return append(validateResourceReferences(refs, path), errs...)
+}
+
+// ValidateProtectedResources checks the protected resources, which have to refer to distinct resource kinds.
+func ValidateProtectedResources(resources []ProtectedResource, path *field.Path) field.ErrorList {
+	refs := make([]ResourceReference, len(resources))
+	errs := field.ErrorList{}
+
+	for i := range resources {
+		refs[i] = resources[i].ResourceReference
+		errs = append(errs, validateProtectedResource(resources[i], path.Index(i))...)
+	}
+
+	return append(validateResourceReferences(refs, path), errs...)
+}
+
+func validateResourceReferences(refs []ResourceReference, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	seen := make(map[schema.GroupVersionKind]bool, len(refs))
+
+	for i, ref := range refs {
+		gvkPath := path.Index(i).Child("ref", "gvk")
+
+		if ref.Kind == "" {
+			errs = append(errs, field.Required(gvkPath.Child("kind"), "resource kind has to be defined"))
+		}
+
+		if ref.Version == "" {
+			errs = append(errs, field.Required(gvkPath.Child("version"), "resource version has to be defined"))
+		}
+
+		if seen[ref.GroupVersionKind] {
+			errs = append(errs, field.Duplicate(gvkPath, ref.GroupVersionKind.String()))
+		}
+
+		seen[ref.GroupVersionKind] = true
+	}
+
+	return errs
+}
+
+func validateProtectedResource(resource ProtectedResource, path *field.Path) field.ErrorList {
+	errs := validateSelectorExpressions(resource.WorkloadSelector, path.Child("workloadSelector"))
+	// Workload selectors of Istio policies can only match labels.
+	errs = append(errs, validateSelectorRequirements(resource.WorkloadMatchExpressions, true, path.Child("workloadMatchExpressions"))...)
+
+	for i, hostPath := range resource.HostPaths {
+		if err := spi.ValidatePathExpression(hostPath); err != nil {
+			errs = append(errs, field.Invalid(path.Child("hostPaths").Index(i), hostPath, err.Error()))
+		}
+	}
+
+	for i, port := range resource.Ports {
+		errs = append(errs, validatePort(port, resource.PortDiscovery, path.Child("ports").Index(i))...)
+	}
+
+	if resource.PeerAuthentication != nil {
+		for port := range resource.PeerAuthentication.PortModes {
+			errs = append(errs, validatePort(port, false, path.Child("peerAuthentication", "portModes").Key(port))...)
+		}
+	}
+
+	return errs
+}
+
+func validatePort(port string, allowNamed bool, path *field.Path) field.ErrorList {
+	number, errConv := strconv.Atoi(port)
+	if errConv == nil {
+		if msgs := validation.IsValidPortNum(number); len(msgs) > 0 {
+			return field.ErrorList{field.Invalid(path, port, strings.Join(msgs, "; "))}
+		}
+
+		return nil
+	}
+
+	if !allowNamed {
+		return field.ErrorList{field.Invalid(path, port, "port has to be a number, named ports require port discovery to be enabled")}
+	}
+
+	if msgs := validation.IsValidPortName(port); len(msgs) > 0 {
+		return field.ErrorList{field.Invalid(path, port, strings.Join(msgs, "; "))}
+	}
+
+	return nil
+}
+
+// validateSelectorExpressions checks the syntax of templated keys and values, and that static ones are valid labels.
+func validateSelectorExpressions(selector map[string]string, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for key, value := range selector {
+		keyPath := path.Key(key)
+
+		if strings.Contains(key, "{{") {
+			if err := config.ParseExpression(key); err != nil {
+				errs = append(errs, field.Invalid(keyPath, key, err.Error()))
+			}
+		} else if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(keyPath, key, strings.Join(msgs, "; ")))
+		}
+
+		if strings.Contains(value, "{{") {
+			if err := config.ParseExpression(value); err != nil {
+				errs = append(errs, field.Invalid(keyPath, value, err.Error()))
+			}
+		} else if msgs := validation.IsValidLabelValue(value); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(keyPath, value, strings.Join(msgs, "; ")))
+		}
+	}
+
+	return errs
+}
+
+//nolint:gochecknoglobals //reason: read-only list of supported values
+var selectorOperators = []string{
+	string(metav1.LabelSelectorOpIn), string(metav1.LabelSelectorOpNotIn),
+	string(metav1.LabelSelectorOpExists), string(metav1.LabelSelectorOpDoesNotExist),
+}
+
+// validateSelectorRequirements checks operators and values of the requirements, as well as their templated keys and values.
+// When matchLabelsOnly is set, only requirements equivalent to matching a label are accepted.
+func validateSelectorRequirements(requirements []metav1.LabelSelectorRequirement, matchLabelsOnly bool, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, requirement := range requirements {
+		reqPath := path.Index(i)
+
+		if strings.Contains(requirement.Key, "{{") {
+			if err := config.ParseExpression(requirement.Key); err != nil {
+				errs = append(errs, field.Invalid(reqPath.Child("key"), requirement.Key, err.Error()))
+			}
+		} else if msgs := validation.IsQualifiedName(requirement.Key); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(reqPath.Child("key"), requirement.Key, strings.Join(msgs, "; ")))
+		}
+
+		for j, value := range requirement.Values {
+			if strings.Contains(value, "{{") {
+				if err := config.ParseExpression(value); err != nil {
+					errs = append(errs, field.Invalid(reqPath.Child("values").Index(j), value, err.Error()))
+				}
+			} else if msgs := validation.IsValidLabelValue(value); len(msgs) > 0 {
+				errs = append(errs, field.Invalid(reqPath.Child("values").Index(j), value, strings.Join(msgs, "; ")))
+			}
+		}
+
+		switch requirement.Operator {
+		case metav1.LabelSelectorOpIn, metav1.LabelSelectorOpNotIn:
+			if len(requirement.Values) == 0 {
+				errs = append(errs, field.Required(reqPath.Child("values"), "values have to be defined for In and NotIn operators"))
+			}
+		case metav1.LabelSelectorOpExists, metav1.LabelSelectorOpDoesNotExist:
+			if len(requirement.Values) > 0 {
+				errs = append(errs, field.Forbidden(reqPath.Child("values"), "values cannot be defined for Exists and DoesNotExist operators"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(reqPath.Child("operator"), requirement.Operator, selectorOperators))
+
+			continue
+		}
+
+		if matchLabelsOnly && !isMatchLabelRequirement(requirement) {
+			errs = append(errs, field.Invalid(reqPath, formatRequirement(requirement),
+				"workload selectors of Istio policies can only match labels, use In operator with a single value instead"))
+		}
+	}
+
+	return errs
+}
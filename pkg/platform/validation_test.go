@@ -0,0 +1,205 @@
+package platform_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/platform"
+	"github.com/opendatahub-io/odh-platform/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Capability validation", test.Unit(), func() {
+
+	protectedResource := func(gvk schema.GroupVersionKind) platform.ProtectedResource {
+		return platform.ProtectedResource{
+			ResourceReference: platform.ResourceReference{GroupVersionKind: gvk},
+			WorkloadSelector:  map[string]string{"component": "{{.metadata.name}}"},
+			Ports:             []string{"8080"},
+			HostPaths:         []string{"status.url"},
+		}
+	}
+
+	It("should accept distinct resource kinds", func() {
+		// given
+		resources := []platform.ProtectedResource{
+			protectedResource(schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Component"}),
+			protectedResource(schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Registry"}),
+		}
+
+		// when
+		errs := platform.ValidateProtectedResources(resources, field.NewPath("protectedResources"))
+
+		// then
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("should point at the entry missing kind and version", func() {
+		// given
+		resources := []platform.ProtectedResource{
+			protectedResource(schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Component"}),
+			protectedResource(schema.GroupVersionKind{Group: "opendatahub.io"}),
+		}
+
+		// when
+		errs := platform.ValidateProtectedResources(resources, field.NewPath("protectedResources"))
+
+		// then
+		Expect(errs.ToAggregate().Error()).To(And(
+			ContainSubstring("protectedResources[1].ref.gvk.kind: Required value"),
+			ContainSubstring("protectedResources[1].ref.gvk.version: Required value"),
+		))
+	})
+
+	It("should reject duplicated resource kinds", func() {
+		// given
+		gvk := schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Component"}
+		targets := []platform.RoutingTarget{
+			{ResourceReference: platform.ResourceReference{GroupVersionKind: gvk}},
+			{ResourceReference: platform.ResourceReference{GroupVersionKind: gvk}},
+		}
+
+		// when
+		errs := platform.ValidateRoutingTargets(targets, field.NewPath("routingTargets"))
+
+		// then
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Type).To(Equal(field.ErrorTypeDuplicate))
+		Expect(errs[0].Field).To(Equal("routingTargets[1].ref.gvk"))
+	})
+
+	It("should point at invalid selector expressions, host paths and ports", func() {
+		// given
+		resource := protectedResource(schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Component"})
+		resource.WorkloadSelector = map[string]string{
+			"component":   "{{.metadata.name}",
+			"invalid key": "value",
+		}
+		resource.HostPaths = []string{"status..url"}
+		resource.Ports = []string{"http", "70000"}
+
+		// when
+		errs := platform.ValidateProtectedResources([]platform.ProtectedResource{resource}, field.NewPath("protectedResources"))
+
+		// then
+		Expect(errs.ToAggregate().Error()).To(And(
+			ContainSubstring(`protectedResources[0].workloadSelector[component]: Invalid value: "{{.metadata.name}"`),
+			ContainSubstring(`protectedResources[0].workloadSelector[invalid key]: Invalid value: "invalid key"`),
+			ContainSubstring(`protectedResources[0].hostPaths[0]: Invalid value: "status..url"`),
+			ContainSubstring(`protectedResources[0].ports[0]: Invalid value: "http": port has to be a number`),
+			ContainSubstring(`protectedResources[0].ports[1]: Invalid value: "70000"`),
+		))
+	})
+
+	It("should accept named ports when port discovery is enabled", func() {
+		// given
+		resource := protectedResource(schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Component"})
+		resource.Ports = []string{"http", "8080"}
+		resource.PortDiscovery = true
+
+		// when
+		errs := platform.ValidateProtectedResources([]platform.ProtectedResource{resource}, field.NewPath("protectedResources"))
+
+		// then
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("should accept resources without ports", func() {
+		// given
+		resource := protectedResource(schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Component"})
+		resource.Ports = []string{}
+
+		// when
+		errs := platform.ValidateProtectedResources([]platform.ProtectedResource{resource}, field.NewPath("protectedResources"))
+
+		// then
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("should accept set-based requirements of Service selectors", func() {
+		// given
+		targets := []platform.RoutingTarget{{
+			ResourceReference: platform.ResourceReference{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Component"}},
+			ServiceMatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "component", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"{{.metadata.name}}-transformer"}},
+				{Key: "serving.kserve.io/inferenceservice", Operator: metav1.LabelSelectorOpExists},
+			},
+		}}
+
+		// when
+		errs := platform.ValidateRoutingTargets(targets, field.NewPath("routingTargets"))
+
+		// then
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("should point at invalid set-based requirements", func() {
+		// given
+		targets := []platform.RoutingTarget{{
+			ResourceReference: platform.ResourceReference{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Component"}},
+			ServiceMatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "component", Operator: "Equals", Values: []string{"a"}},
+				{Key: "component", Operator: metav1.LabelSelectorOpIn},
+				{Key: "component", Operator: metav1.LabelSelectorOpExists, Values: []string{"a"}},
+				{Key: "{{.metadata.name", Operator: metav1.LabelSelectorOpIn, Values: []string{"{{.kind}"}},
+			},
+		}}
+
+		// when
+		errs := platform.ValidateRoutingTargets(targets, field.NewPath("routingTargets"))
+
+		// then
+		Expect(errs.ToAggregate().Error()).To(And(
+			ContainSubstring(`routingTargets[0].serviceMatchExpressions[0].operator: Unsupported value: "Equals"`),
+			ContainSubstring(`routingTargets[0].serviceMatchExpressions[1].values: Required value`),
+			ContainSubstring(`routingTargets[0].serviceMatchExpressions[2].values: Forbidden`),
+			ContainSubstring(`routingTargets[0].serviceMatchExpressions[3].key: Invalid value: "{{.metadata.name"`),
+			ContainSubstring(`routingTargets[0].serviceMatchExpressions[3].values[0]: Invalid value: "{{.kind}"`),
+		))
+	})
+
+	It("should explain workload requirements which cannot be applied to Istio policies", func() {
+		// given
+		resource := protectedResource(schema.GroupVersionKind{Group: "opendatahub.io", Version: "v1", Kind: "Component"})
+		resource.WorkloadMatchExpressions = []metav1.LabelSelectorRequirement{
+			{Key: "component", Operator: metav1.LabelSelectorOpIn, Values: []string{"{{.metadata.name}}-predictor"}},
+			{Key: "component", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"transformer"}},
+			{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+		}
+
+		// when
+		errs := platform.ValidateProtectedResources([]platform.ProtectedResource{resource}, field.NewPath("protectedResources"))
+
+		// then
+		Expect(errs).To(HaveLen(2))
+		Expect(errs.ToAggregate().Error()).To(And(
+			ContainSubstring(`protectedResources[0].workloadMatchExpressions[1]: Invalid value: "component NotIn (transformer)": workload selectors of Istio policies can only match labels`),
+			ContainSubstring(`protectedResources[0].workloadMatchExpressions[2]: Invalid value: "app In (a, b)"`),
+		))
+	})
+})
+
+var _ = Describe("Match labels requirements", test.Unit(), func() {
+
+	It("should convert single value In requirements to labels", func() {
+		// when
+		matchLabels, err := platform.RequirementsAsMatchLabels([]metav1.LabelSelectorRequirement{
+			{Key: "component", Operator: metav1.LabelSelectorOpIn, Values: []string{"predictor"}},
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matchLabels).To(Equal(map[string]string{"component": "predictor"}))
+	})
+
+	It("should explain requirements which have no equivalent labels", func() {
+		// when
+		_, err := platform.RequirementsAsMatchLabels([]metav1.LabelSelectorRequirement{
+			{Key: "component", Operator: metav1.LabelSelectorOpDoesNotExist},
+		})
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring(`requirement "component DoesNotExist" has no equivalent in selectors matching labels only`)))
+	})
+})
@@ -0,0 +1,207 @@
+//go:build !ignore_autogenerated
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package platform
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessCheck) DeepCopyInto(out *AccessCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessCheck.
+func (in *AccessCheck) DeepCopy() *AccessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnforcementScope) DeepCopyInto(out *EnforcementScope) {
+	*out = *in
+	if in.ExemptPrincipals != nil {
+		in, out := &in.ExemptPrincipals, &out.ExemptPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnforcementScope.
+func (in *EnforcementScope) DeepCopy() *EnforcementScope {
+	if in == nil {
+		return nil
+	}
+	out := new(EnforcementScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MTLSConfig) DeepCopyInto(out *MTLSConfig) {
+	*out = *in
+	if in.PortModes != nil {
+		in, out := &in.PortModes, &out.PortModes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSConfig.
+func (in *MTLSConfig) DeepCopy() *MTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PathExclusion) DeepCopyInto(out *PathExclusion) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PathExclusion.
+func (in *PathExclusion) DeepCopy() *PathExclusion {
+	if in == nil {
+		return nil
+	}
+	out := new(PathExclusion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectedResource) DeepCopyInto(out *ProtectedResource) {
+	*out = *in
+	out.ResourceReference = in.ResourceReference
+	if in.WorkloadSelector != nil {
+		in, out := &in.WorkloadSelector, &out.WorkloadSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WorkloadMatchExpressions != nil {
+		in, out := &in.WorkloadMatchExpressions, &out.WorkloadMatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostPaths != nil {
+		in, out := &in.HostPaths, &out.HostPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.AccessCheck = in.AccessCheck
+	if in.UnprotectedPaths != nil {
+		in, out := &in.UnprotectedPaths, &out.UnprotectedPaths
+		*out = make([]PathExclusion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PeerAuthentication != nil {
+		in, out := &in.PeerAuthentication, &out.PeerAuthentication
+		*out = new(MTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdentityHeaders != nil {
+		in, out := &in.IdentityHeaders, &out.IdentityHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnforcementScope != nil {
+		in, out := &in.EnforcementScope, &out.EnforcementScope
+		*out = new(EnforcementScope)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedResource.
+func (in *ProtectedResource) DeepCopy() *ProtectedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
+	*out = *in
+	out.GroupVersionKind = in.GroupVersionKind
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReference.
+func (in *ResourceReference) DeepCopy() *ResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingTarget) DeepCopyInto(out *RoutingTarget) {
+	*out = *in
+	out.ResourceReference = in.ResourceReference
+	if in.ServiceSelector != nil {
+		in, out := &in.ServiceSelector, &out.ServiceSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceMatchExpressions != nil {
+		in, out := &in.ServiceMatchExpressions, &out.ServiceMatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingTarget.
+func (in *RoutingTarget) DeepCopy() *RoutingTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingTarget)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/opendatahub-io/odh-platform/pkg/config"
 	"github.com/opendatahub-io/odh-platform/pkg/schema"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -60,7 +61,7 @@ func (s *staticTemplateLoader) Load(data *ExposedServiceConfig, routeType RouteT
 }
 
 func (s *staticTemplateLoader) resolveTemplate(tmpl []byte, data *ExposedServiceConfig) ([]byte, error) {
-	engine, err := template.New("routing").Parse(string(tmpl))
+	engine, err := template.New("routing").Funcs(config.TemplateFuncs()).Parse(string(tmpl))
 	if err != nil {
 		return []byte{}, fmt.Errorf("could not create template engine: %w", err)
 	}
@@ -45,6 +45,53 @@ var _ = Describe("Host extraction", test.Unit(), func() {
 		Expect(hosts).To(ContainElements("test.com", "test2.com"))
 	})
 
+	Context("using JSONPath expressions", func() {
+
+		target := unstructured.Unstructured{
+			Object: map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]any{
+						"example.com/host": "annotated.example.com",
+					},
+				},
+				"status": map[string]any{
+					"addresses": []any{
+						map[string]any{"name": "external", "url": "https://model.example.com"},
+						map[string]any{"name": "internal", "url": "http://model.ns.svc.cluster.local"},
+					},
+				},
+			},
+		}
+
+		DescribeTable("should extract hosts",
+			func(path string, expected ...string) {
+				// when
+				hosts, err := spi.NewPathExpressionExtractor([]string{path})(&target)
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(hosts).To(HaveExactElements(expected))
+			},
+			Entry("from list elements", "status.addresses[*].url", "https://model.example.com", "http://model.ns.svc.cluster.local"),
+			Entry("using condition", `{.status.addresses[?(@.name=="external")].url}`, "https://model.example.com"),
+			Entry("using keys containing dots", `{.metadata.annotations.example\.com/host}`, "annotated.example.com"),
+		)
+
+		It("should fail when expression does not resolve to strings", func() {
+			// when
+			_, err := spi.NewPathExpressionExtractor([]string{"status.addresses[0]"})(&target)
+
+			// then
+			Expect(err).To(MatchError(ContainSubstring("expected string or list of strings")))
+		})
+
+		It("should reject invalid expression", func() {
+			Expect(spi.ValidatePathExpression("status.addresses[?(@.name==")).To(MatchError(ContainSubstring("invalid JSONPath expression")))
+			Expect(spi.ValidatePathExpression("status..url")).To(HaveOccurred())
+			Expect(spi.ValidatePathExpression("status.addresses[*].url")).To(Succeed())
+		})
+	})
+
 	It("should return unique list", func() {
 
 		// given
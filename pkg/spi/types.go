@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 // HostExtractor attempts to extract Hosts from the given resource.
@@ -93,30 +94,31 @@ func UnifiedHostExtractor(extractors ...HostExtractor) HostExtractor { //nolint:
 	}
 }
 
-func NewPathExpressionExtractor(paths []string) HostExtractor {
-	extractHosts := func(target *unstructured.Unstructured, splitPath []string) ([]string, error) {
-		// extracting as string
-		if foundHost, found, err := unstructured.NestedString(target.Object, splitPath...); err == nil && found {
-			return []string{foundHost}, nil
-		}
+// jsonPathChars are characters which can only be used in JSONPath expressions, not in dot-separated paths.
+const jsonPathChars = "{}[]*?@()$\\'\""
 
-		// extracting as slice of strings
-		if foundHosts, found, err := unstructured.NestedStringSlice(target.Object, splitPath...); err == nil && found {
-			return foundHosts, nil
-		}
+// ValidatePathExpression checks the syntax of the path expected by NewPathExpressionExtractor.
+func ValidatePathExpression(path string) error {
+	_, err := compilePathExpression(path)
 
-		return nil, fmt.Errorf("neither string nor slice of strings found at path %v", splitPath)
-	}
+	return err
+}
 
+// NewPathExpressionExtractor extracts hosts from fields of the resource. Each of the paths is either:
+//   - dot-separated field names, e.g. "status.url",
+//   - JSONPath expression, e.g. "status.addresses[*].url" or "{.status.addresses[?(@.name=="external")].url}",
+//     which can address list elements, keys containing dots ("{.metadata.annotations.example\.com/host}")
+//     and conditional values. Expressions not enclosed in braces are resolved relative to the resource.
+//
+// Fields have to hold strings or lists of strings.
+func NewPathExpressionExtractor(paths []string) HostExtractor {
 	return func(target *unstructured.Unstructured) ([]string, error) {
 		var errExtract []error
 
 		hosts := []string{}
 
 		for _, path := range paths {
-			splitPath := strings.Split(path, ".")
-			extractedHosts, err := extractHosts(target, splitPath)
-
+			extractedHosts, err := extractHostsAt(target, path)
 			if err != nil {
 				errExtract = append(errExtract, fmt.Errorf("failed to extract hosts at path %s: %w", path, err))
 			}
@@ -127,3 +129,126 @@ func NewPathExpressionExtractor(paths []string) HostExtractor {
 		return hosts, errors.Join(errExtract...)
 	}
 }
+
+func extractHostsAt(target *unstructured.Unstructured, path string) ([]string, error) {
+	extract, err := compilePathExpression(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return extract(target.Object)
+}
+
+type pathExtractFunc func(object map[string]any) ([]string, error)
+
+func compilePathExpression(path string) (pathExtractFunc, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("path is empty")
+	}
+
+	if !strings.ContainsAny(path, jsonPathChars) {
+		return compileDottedPath(path)
+	}
+
+	expression := path
+	if !strings.HasPrefix(expression, "{") {
+		if !strings.HasPrefix(expression, ".") {
+			expression = "." + expression
+		}
+
+		expression = "{" + expression + "}"
+	}
+
+	// JSONPath keeps parsing state, so it is created for each of the extractions.
+	parse := func() (*jsonpath.JSONPath, error) {
+		parser := jsonpath.New(path)
+		if errParse := parser.Parse(expression); errParse != nil {
+			return nil, fmt.Errorf("invalid JSONPath expression: %w", errParse)
+		}
+
+		return parser, nil
+	}
+
+	if _, errParse := parse(); errParse != nil {
+		return nil, errParse
+	}
+
+	return func(object map[string]any) ([]string, error) {
+		parser, errParse := parse()
+		if errParse != nil {
+			return nil, errParse
+		}
+
+		results, errFind := parser.FindResults(object)
+		if errFind != nil {
+			return nil, fmt.Errorf("failed evaluating JSONPath expression: %w", errFind)
+		}
+
+		var hosts []string
+
+		for _, result := range results {
+			for _, value := range result {
+				found, errConv := toStrings(value.Interface())
+				if errConv != nil {
+					return nil, errConv
+				}
+
+				hosts = append(hosts, found...)
+			}
+		}
+
+		return hosts, nil
+	}, nil
+}
+
+func compileDottedPath(path string) (pathExtractFunc, error) {
+	splitPath := strings.Split(path, ".")
+
+	for _, segment := range splitPath {
+		if segment == "" {
+			return nil, errors.New("path contains empty segment, fields are expected to be separated by a single dot")
+		}
+
+		if strings.ContainsAny(segment, " \t") {
+			return nil, fmt.Errorf("segment %q is not a field name", segment)
+		}
+	}
+
+	return func(object map[string]any) ([]string, error) {
+		// extracting as string
+		if foundHost, found, err := unstructured.NestedString(object, splitPath...); err == nil && found {
+			return []string{foundHost}, nil
+		}
+
+		// extracting as slice of strings
+		if foundHosts, found, err := unstructured.NestedStringSlice(object, splitPath...); err == nil && found {
+			return foundHosts, nil
+		}
+
+		return nil, fmt.Errorf("neither string nor slice of strings found at path %v", splitPath)
+	}, nil
+}
+
+func toStrings(value any) ([]string, error) {
+	switch typed := value.(type) {
+	case string:
+		return []string{typed}, nil
+	case []string:
+		return typed, nil
+	case []any:
+		values := make([]string, 0, len(typed))
+
+		for _, elem := range typed {
+			str, isString := elem.(string)
+			if !isString {
+				return nil, fmt.Errorf("expected list of strings, found element of type %T", elem)
+			}
+
+			values = append(values, str)
+		}
+
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("expected string or list of strings, found %T", value)
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-logr/logr"
+	"github.com/opendatahub-io/odh-platform/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/opendatahub-io/odh-platform"
+
+// Attributes describing the reconciled resource.
+const (
+	GVKKey         = attribute.Key("odh.resource.gvk")
+	NamespaceKey   = attribute.Key("k8s.namespace.name")
+	NameKey        = attribute.Key("odh.resource.name")
+	ExportModesKey = attribute.Key("odh.routing.export_modes")
+)
+
+// Options defines how spans are exported.
+type Options struct {
+	// Endpoint is the URL of OTLP/HTTP traces endpoint, e.g. "http://otel-collector:4318/v1/traces".
+	// Tracing is disabled when empty.
+	Endpoint string
+	// ServiceName identifies the manager in the tracing backend.
+	ServiceName string
+	// SampleRatio is the fraction of reconciliations which are traced, between 0 and 1.
+	SampleRatio float64
+}
+
+// Setup registers global tracer provider exporting spans to the configured endpoint. When tracing is disabled,
+// the default no-op provider is kept, so that instrumented code does not record anything.
+// The returned function flushes remaining spans and has to be called before the process exits.
+func Setup(log logr.Logger, opts Options) (func(ctx context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if opts.Endpoint == "" {
+		return noop, nil
+	}
+
+	endpoint, errURL := url.Parse(opts.Endpoint)
+	if errURL != nil {
+		return noop, fmt.Errorf("invalid traces endpoint %q: %w", opts.Endpoint, errURL)
+	}
+
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return noop, fmt.Errorf("invalid traces endpoint %q: only http and https schemes are supported", opts.Endpoint)
+	}
+
+	if opts.SampleRatio < 0 || opts.SampleRatio > 1 {
+		return noop, fmt.Errorf("invalid sample ratio %v: it has to be between 0 and 1", opts.SampleRatio)
+	}
+
+	otel.SetLogger(log)
+
+	// The exporter reads other standard OTEL_EXPORTER_OTLP_* variables itself, e.g. headers or timeout.
+	// The endpoint option takes precedence over the variables, so that the flag stays the source of truth.
+	exporter, errExporter := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(opts.Endpoint))
+	if errExporter != nil {
+		return noop, fmt.Errorf("failed creating traces exporter: %w", errExporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(exporter)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", opts.ServiceName),
+			attribute.String("service.version", version.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start creates a span which is a child of the span in the context, if any.
+//
+//nolint:ireturn,spancheck //reason the span is the one of OpenTelemetry API, it is ended by the caller using End
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End marks the span as failed when err is not nil and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// LogValues returns ID of the trace in the context as logger key-value pairs, so that log lines can be correlated
+// with the trace. Nothing is returned when the context is not traced.
+func LogValues(ctx context.Context) []any {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() || !spanContext.IsSampled() {
+		return nil
+	}
+
+	return []any{"traceID", spanContext.TraceID().String()}
+}
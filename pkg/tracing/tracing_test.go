@@ -0,0 +1,109 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opendatahub-io/odh-platform/pkg/tracing"
+	"github.com/opendatahub-io/odh-platform/test"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var _ = Describe("Exporting traces", test.Unit(), func() {
+
+	var (
+		collector *httptest.Server
+		mu        sync.Mutex
+		requests  []*http.Request
+	)
+
+	BeforeEach(func() {
+		requests = nil
+
+		collector = httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			requests = append(requests, r.Clone(context.Background()))
+		}))
+
+		DeferCleanup(func() {
+			collector.Close()
+			otel.SetTracerProvider(noop.NewTracerProvider())
+		})
+	})
+
+	It("should do nothing when endpoint is not configured", func(ctx context.Context) {
+		shutdown, err := tracing.Setup(logr.Discard(), tracing.Options{})
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx, span := tracing.Start(ctx, "reconcile")
+		tracing.End(span, nil)
+
+		Expect(span.SpanContext().IsValid()).To(BeFalse())
+		Expect(tracing.LogValues(ctx)).To(BeEmpty())
+		Expect(shutdown(ctx)).To(Succeed())
+	})
+
+	It("should reject invalid options", func() {
+		_, errScheme := tracing.Setup(logr.Discard(), tracing.Options{Endpoint: "otel-collector:4317", SampleRatio: 1})
+		Expect(errScheme).To(MatchError(ContainSubstring("only http and https schemes are supported")))
+
+		_, errRatio := tracing.Setup(logr.Discard(), tracing.Options{Endpoint: collector.URL, SampleRatio: 1.5})
+		Expect(errRatio).To(MatchError(ContainSubstring("has to be between 0 and 1")))
+	})
+
+	It("should export sampled spans to the configured endpoint", func(ctx context.Context) {
+		GinkgoT().Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer%20token")
+
+		shutdown, err := tracing.Setup(logr.Discard(), tracing.Options{
+			Endpoint:    collector.URL + "/v1/traces",
+			ServiceName: "odh-platform-test",
+			SampleRatio: 1,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		reconcileCtx, reconcileSpan := tracing.Start(ctx, "routing-service/Reconcile",
+			tracing.NamespaceKey.String("test-ns"),
+		)
+		_, stepSpan := tracing.Start(reconcileCtx, "createRoutingResources")
+		tracing.End(stepSpan, errors.New("no exported services"))
+		tracing.End(reconcileSpan, nil)
+
+		Expect(tracing.LogValues(reconcileCtx)).To(Equal([]any{"traceID", reconcileSpan.SpanContext().TraceID().String()}))
+
+		Expect(shutdown(ctx)).To(Succeed())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].URL.Path).To(Equal("/v1/traces"))
+		Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/x-protobuf"))
+		Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer token"))
+	})
+
+	It("should not trace reconciliations outside of the sample", func(ctx context.Context) {
+		shutdown, err := tracing.Setup(logr.Discard(), tracing.Options{Endpoint: collector.URL, SampleRatio: 0})
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx, span := tracing.Start(ctx, "authorization-service/Reconcile")
+		tracing.End(span, nil)
+
+		Expect(span.SpanContext().IsSampled()).To(BeFalse())
+		Expect(tracing.LogValues(ctx)).To(BeEmpty())
+		Expect(shutdown(ctx)).To(Succeed())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		Expect(requests).To(BeEmpty())
+	})
+})
@@ -2,44 +2,121 @@ package unstruct
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/opendatahub-io/odh-platform/pkg/metadata"
+	"github.com/opendatahub-io/odh-platform/pkg/tracing"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 func Apply(ctx context.Context, cli client.Client, objects []*unstructured.Unstructured, metaOptions ...metadata.Option) error {
+	return ApplyObserved(ctx, cli, objects, nil, metaOptions...)
+}
+
+// ApplyObserved works like Apply, and additionally calls observe (when defined) with the desired state of each object
+// and its state returned by the API server.
+func ApplyObserved(ctx context.Context, cli client.Client, objects []*unstructured.Unstructured,
+	observe func(desired, applied *unstructured.Unstructured), metaOptions ...metadata.Option) error {
+	return applyEach(ctx, cli, objects, createOrPatch, observe, metaOptions...)
+}
+
+// ServerSideApply ensures the objects are in the desired state using server-side apply only, which also creates
+// objects that do not exist yet. It calls observe (when defined) with the desired state of each object and its state
+// returned by the API server.
+func ServerSideApply(ctx context.Context, cli client.Client, objects []*unstructured.Unstructured,
+	observe func(desired, applied *unstructured.Unstructured), metaOptions ...metadata.Option) error {
+	return applyEach(ctx, cli, objects, patchUsingApplyStrategy, observe, metaOptions...)
+}
+
+type applyFunc func(ctx context.Context, cli client.Client, source, target *unstructured.Unstructured) error
+
+func applyEach(ctx context.Context, cli client.Client, objects []*unstructured.Unstructured, apply applyFunc,
+	observe func(desired, applied *unstructured.Unstructured), metaOptions ...metadata.Option) error {
 	for _, source := range objects {
 		metadata.ApplyMetaOptions(source, metaOptions...)
 
 		target := source.DeepCopy()
 
-		name := source.GetName()
-		namespace := source.GetNamespace()
+		if errApply := applyTraced(ctx, cli, source, target, apply); errApply != nil {
+			return errApply
+		}
 
-		errGet := cli.Get(ctx, k8stypes.NamespacedName{Name: name, Namespace: namespace}, target)
-		if client.IgnoreNotFound(errGet) != nil {
-			return fmt.Errorf("failed to get resource %s/%s: %w", namespace, name, errGet)
+		if observe != nil {
+			observe(source, target)
 		}
+	}
+
+	return nil
+}
+
+func applyTraced(ctx context.Context, cli client.Client, source, target *unstructured.Unstructured, apply applyFunc) error {
+	ctx, span := tracing.Start(ctx, "apply",
+		tracing.GVKKey.String(source.GroupVersionKind().String()),
+		tracing.NamespaceKey.String(source.GetNamespace()),
+		tracing.NameKey.String(source.GetName()),
+	)
+
+	err := apply(ctx, cli, source, target)
+	tracing.End(span, err)
+
+	return err
+}
+
+// createOrPatch creates the resource when it does not exist yet. Otherwise, it is patched using server-side apply.
+func createOrPatch(ctx context.Context, cli client.Client, source, target *unstructured.Unstructured) error {
+	name := source.GetName()
+	namespace := source.GetNamespace()
+
+	errGet := cli.Get(ctx, k8stypes.NamespacedName{Name: name, Namespace: namespace}, target)
+	if client.IgnoreNotFound(errGet) != nil {
+		return fmt.Errorf("failed to get resource %s/%s: %w", namespace, name, errGet)
+	}
 
-		if k8serr.IsNotFound(errGet) {
-			if errCreate := cli.Create(ctx, target); client.IgnoreAlreadyExists(errCreate) != nil { //nolint:gocritic //reason: we don't want to treat AlreadyExists as error here
-				return fmt.Errorf("failed to create source %s/%s: %w", namespace, name, errCreate)
-			}
-		} else {
-			if errUpdate := patchUsingApplyStrategy(ctx, cli, source, target); errUpdate != nil {
-				return fmt.Errorf("failed to reconcile resource %s/%s: %w", namespace, name, errUpdate)
-			}
+	if k8serr.IsNotFound(errGet) {
+		if errCreate := cli.Create(ctx, target); client.IgnoreAlreadyExists(errCreate) != nil { //nolint:gocritic //reason: we don't want to treat AlreadyExists as error here
+			return fmt.Errorf("failed to create source %s/%s: %w", namespace, name, errCreate)
+		}
+	} else {
+		if errUpdate := patchUsingApplyStrategy(ctx, cli, source, target); errUpdate != nil {
+			return fmt.Errorf("failed to reconcile resource %s/%s: %w", namespace, name, errUpdate)
 		}
 	}
 
 	return nil
 }
 
+// ToUnstructured converts typed object to its unstructured representation suitable for ServerSideApply.
+// Status and creation timestamp are dropped, as these fields are never owned by the applying party.
+func ToUnstructured(obj client.Object, scheme *runtime.Scheme) (*unstructured.Unstructured, error) {
+	gvk, errGVK := apiutil.GVKForObject(obj, scheme)
+	if errGVK != nil {
+		return nil, fmt.Errorf("failed to determine GroupVersionKind of %T: %w", obj, errGVK)
+	}
+
+	data, errMarshal := json.Marshal(obj)
+	if errMarshal != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", gvk.String(), errMarshal)
+	}
+
+	converted := &unstructured.Unstructured{}
+	if errUnmarshal := json.Unmarshal(data, &converted.Object); errUnmarshal != nil {
+		return nil, fmt.Errorf("failed to convert %s to unstructured: %w", gvk.String(), errUnmarshal)
+	}
+
+	converted.SetGroupVersionKind(gvk)
+	unstructured.RemoveNestedField(converted.Object, "status")
+	unstructured.RemoveNestedField(converted.Object, "metadata", "creationTimestamp")
+
+	return converted, nil
+}
+
 // patchUsingApplyStrategy performs server-side apply [1] patch to a Kubernetes resource.
 // It treats the provided source as the desired state of the resource and attempts to
 // reconcile the target resource to match this state. The function takes ownership of the
@@ -53,7 +130,7 @@ func patchUsingApplyStrategy(ctx context.Context, cli client.Client, source, tar
 	}
 
 	if errPatch := cli.Patch(ctx, target, client.RawPatch(k8stypes.ApplyPatchType, data), client.ForceOwnership, client.FieldOwner("odh-platform")); errPatch != nil {
-		return fmt.Errorf("failed to apply patch to %s: %w", source.GroupVersionKind().String(), errPatch)
+		return fmt.Errorf("failed to apply patch to %s %s/%s: %w", source.GroupVersionKind().String(), source.GetNamespace(), source.GetName(), errPatch)
 	}
 
 	return nil
@@ -65,7 +142,10 @@ func IsMarkedForDeletion(target *unstructured.Unstructured) bool {
 
 // Patch updates the specified Kubernetes resource by applying changes from the provided target object.
 // In case of conflicts, it will retry using default strategy.
-func Patch(ctx context.Context, cli client.Client, target *unstructured.Unstructured) error {
+func Patch(ctx context.Context, cli client.Client, target *unstructured.Unstructured) (errMetaPatch error) {
+	ctx, span := tracing.Start(ctx, "patch")
+	defer func() { tracing.End(span, errMetaPatch) }()
+
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		currentRes := &unstructured.Unstructured{}
 		currentRes.SetGroupVersionKind(target.GroupVersionKind())
@@ -88,3 +168,18 @@ func Patch(ctx context.Context, cli client.Client, target *unstructured.Unstruct
 
 	return nil
 }
+
+// PatchFrom sends changes made to target since original has been fetched as a merge patch, so that fields set by other
+// writers in the meantime are left intact. The patch is rejected with Conflict error when the resource has been modified
+// since then, in which case the caller is expected to reconcile it again.
+func PatchFrom(ctx context.Context, cli client.Client, original, target *unstructured.Unstructured) (errPatch error) {
+	ctx, span := tracing.Start(ctx, "patch")
+	defer func() { tracing.End(span, errPatch) }()
+
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+	if err := cli.Patch(ctx, target, patch); err != nil {
+		return fmt.Errorf("failed to patch %s %s/%s: %w", target.GroupVersionKind().String(), target.GetNamespace(), target.GetName(), err)
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ func StartWithControllers(ctrls ...controllers.SetupWithManagerFunc) (*k8senvtes
 
 	return k8senvtest.Configure(
 		k8senvtest.WithCRDs(
+			filepath.Join(ProjectRoot(), "config", "crd", "bases"),
 			filepath.Join(ProjectRoot(), "config", "crd", "external"),
 			filepath.Join(ProjectRoot(), "test", "data", "crds"),
 		),